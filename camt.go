@@ -0,0 +1,661 @@
+package mt940_converter
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+type CamtVersion string
+
+const (
+	CAMT_053_001_02 CamtVersion = "camt.053.001.02"
+	CAMT_053_001_08             = "camt.053.001.08"
+	CAMT_054_001_02             = "camt.054.001.02"
+	CAMT_054_001_08             = "camt.054.001.08"
+)
+
+const camtNamespace = "urn:iso:std:iso:20022:tech:xsd:"
+
+const (
+	camtCredit  = "CRDT"
+	camtDebit   = "DBIT"
+	camtBooked  = "BOOK"
+	camtPending = "PDNG"
+	camtIssuer  = "SWIFT"
+)
+
+const (
+	maxCamtIdentifierLength  = 35
+	maxCamtAccountLength     = 34
+	maxCamtNameLength        = 140
+	maxCamtRemittanceLength  = 140
+	maxCamtInformationLength = 500
+	maxReferenceNumberLength = 16
+)
+
+var (
+	camtTransactionCodePattern = regexp.MustCompile(`^[NFS][A-Z0-9]{3}$`)
+	camtBICPattern             = regexp.MustCompile(`^[A-Z]{6}[A-Z2-9][A-NP-Z0-9]([A-Z0-9]{3})?$`)
+)
+
+var camtBalanceTypes = map[string]BalanceType{
+	"OPBD": OPENING,
+	"PRCD": OPENING,
+	"CLBD": CLOSING,
+	"CLAV": AVAILABLE,
+	"ITAV": AVAILABLE,
+	"FWAV": FORWARD_AVAILABLE,
+}
+
+var camtBalanceCodes = map[BalanceType]string{
+	OPENING:              "OPBD",
+	CLOSING:              "CLBD",
+	AVAILABLE:            "CLAV",
+	FORWARD_AVAILABLE:    "FWAV",
+	INTERMEDIATE_OPENING: "ITBD",
+	INTERMEDIATE_CLOSING: "ITBD",
+}
+
+type camtDocument struct {
+	XMLName      xml.Name
+	Statement    *camtMessage `xml:"BkToCstmrStmt,omitempty"`
+	Report       *camtMessage `xml:"BkToCstmrAcctRpt,omitempty"`
+	Notification *camtMessage `xml:"BkToCstmrDbtCdtNtfctn,omitempty"`
+}
+
+type camtMessage struct {
+	GroupHeader   camtGroupHeader `xml:"GrpHdr"`
+	Statements    []camtStatement `xml:"Stmt"`
+	Reports       []camtStatement `xml:"Rpt"`
+	Notifications []camtStatement `xml:"Ntfctn"`
+}
+
+type camtGroupHeader struct {
+	MessageID        string `xml:"MsgId"`
+	CreationDateTime string `xml:"CreDtTm"`
+}
+
+type camtStatement struct {
+	ID                       string          `xml:"Id"`
+	Pagination               *camtPagination `xml:"StmtPgntn,omitempty"`
+	ReportPagination         *camtPagination `xml:"RptPgntn,omitempty"`
+	NotificationPagination   *camtPagination `xml:"NtfctnPgntn,omitempty"`
+	ElectronicSequenceNumber string          `xml:"ElctrncSeqNb,omitempty"`
+	CreationDateTime         string          `xml:"CreDtTm"`
+	Account                  camtAccount     `xml:"Acct"`
+	Balances                 []camtBalance   `xml:"Bal"`
+	Summary                  *camtSummary    `xml:"TxsSummry,omitempty"`
+	Entries                  []camtEntry     `xml:"Ntry"`
+	AdditionalInformation    string          `xml:"AddtlStmtInf,omitempty"`
+	ReportInformation        string          `xml:"AddtlRptInf,omitempty"`
+	NotificationInformation  string          `xml:"AddtlNtfctnInf,omitempty"`
+}
+
+type camtPagination struct {
+	PageNumber string `xml:"PgNb"`
+	LastPage   bool   `xml:"LastPgInd"`
+}
+
+type camtAccount struct {
+	ID       camtAccountID `xml:"Id"`
+	Currency string        `xml:"Ccy,omitempty"`
+}
+
+type camtAccountID struct {
+	IBAN  string       `xml:"IBAN,omitempty"`
+	Other *camtOtherID `xml:"Othr,omitempty"`
+}
+
+type camtOtherID struct {
+	ID string `xml:"Id"`
+}
+
+type camtSummary struct {
+	Credit *camtNumberAndSum `xml:"TtlCdtNtries,omitempty"`
+	Debit  *camtNumberAndSum `xml:"TtlDbtNtries,omitempty"`
+}
+
+type camtNumberAndSum struct {
+	Count string `xml:"NbOfNtries,omitempty"`
+	Sum   string `xml:"Sum,omitempty"`
+}
+
+type camtBalance struct {
+	Type        camtBalanceType `xml:"Tp"`
+	Amount      camtAmount      `xml:"Amt"`
+	CreditDebit string          `xml:"CdtDbtInd"`
+	Date        camtDate        `xml:"Dt"`
+}
+
+type camtBalanceType struct {
+	CodeOrProprietary camtCode `xml:"CdOrPrtry"`
+}
+
+type camtCode struct {
+	Code string `xml:"Cd"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt,omitempty"`
+	DateTime string `xml:"DtTm,omitempty"`
+}
+
+type camtEntry struct {
+	Reference             string                  `xml:"NtryRef,omitempty"`
+	Amount                camtAmount              `xml:"Amt"`
+	CreditDebit           string                  `xml:"CdtDbtInd"`
+	Reversal              bool                    `xml:"RvslInd,omitempty"`
+	Status                camtStatus              `xml:"Sts"`
+	BookingDate           *camtDate               `xml:"BookgDt,omitempty"`
+	ValueDate             *camtDate               `xml:"ValDt,omitempty"`
+	ServicerReference     string                  `xml:"AcctSvcrRef,omitempty"`
+	BankTransactionCode   camtBankTransactionCode `xml:"BkTxCd"`
+	Details               []camtEntryDetails      `xml:"NtryDtls,omitempty"`
+	AdditionalInformation string                  `xml:"AddtlNtryInf,omitempty"`
+}
+
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd,omitempty"`
+}
+
+type camtBankTransactionCode struct {
+	Proprietary *camtProprietaryCode `xml:"Prtry,omitempty"`
+}
+
+type camtProprietaryCode struct {
+	Code   string `xml:"Cd"`
+	Issuer string `xml:"Issr,omitempty"`
+}
+
+type camtEntryDetails struct {
+	Transactions []camtTransactionDetails `xml:"TxDtls"`
+}
+
+type camtTransactionDetails struct {
+	References            *camtReferences     `xml:"Refs,omitempty"`
+	RelatedParties        *camtRelatedParties `xml:"RltdPties,omitempty"`
+	RelatedAgents         *camtRelatedAgents  `xml:"RltdAgts,omitempty"`
+	Remittance            *camtRemittance     `xml:"RmtInf,omitempty"`
+	AdditionalInformation string              `xml:"AddtlTxInf,omitempty"`
+}
+
+type camtReferences struct {
+	EndToEndID string `xml:"EndToEndId,omitempty"`
+	MandateID  string `xml:"MndtId,omitempty"`
+}
+
+type camtRelatedParties struct {
+	Debtor          *camtParty   `xml:"Dbtr,omitempty"`
+	DebtorAccount   *camtAccount `xml:"DbtrAcct,omitempty"`
+	Creditor        *camtParty   `xml:"Cdtr,omitempty"`
+	CreditorAccount *camtAccount `xml:"CdtrAcct,omitempty"`
+}
+
+type camtRelatedAgents struct {
+	DebtorAgent   *camtAgent `xml:"DbtrAgt,omitempty"`
+	CreditorAgent *camtAgent `xml:"CdtrAgt,omitempty"`
+}
+
+type camtAgent struct {
+	FinancialInstitution camtFinancialInstitution `xml:"FinInstnId"`
+}
+
+type camtFinancialInstitution struct {
+	BIC   string `xml:"BIC,omitempty"`
+	BICFI string `xml:"BICFI,omitempty"`
+}
+
+type camtParty struct {
+	Name  string     `xml:"Nm,omitempty"`
+	Party *camtParty `xml:"Pty,omitempty"`
+}
+
+type camtRemittance struct {
+	Unstructured []string `xml:"Ustrd"`
+}
+
+func (v CamtVersion) isVersion08() bool {
+	return strings.HasSuffix(string(v), ".08")
+}
+
+func newCamtAccount(account AccountIdentification, currency string) camtAccount {
+	return camtAccount{ID: newCamtAccountID(accountNumber(account)), Currency: currency}
+}
+
+func newCamtAccountID(iban string) camtAccountID {
+	if ibanPattern.MatchString(iban) {
+		return camtAccountID{IBAN: iban}
+	}
+	return camtAccountID{Other: &camtOtherID{ID: truncate(iban, maxCamtAccountLength)}}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func truncate(input string, length int) string {
+	if utf8.RuneCountInString(input) <= length {
+		return input
+	}
+	return string([]rune(input)[:length])
+}
+
+func splitText(input string, length int) []string {
+	var result []string
+	runes := []rune(strings.Join(strings.Fields(input), " "))
+	for len(runes) > length {
+		result = append(result, string(runes[:length]))
+		runes = runes[length:]
+	}
+	if len(runes) > 0 {
+		result = append(result, string(runes))
+	}
+	return result
+}
+
+func parseCamtDocument(input string, message string) (*camtDocument, error) {
+	var document camtDocument
+	if err := xml.Unmarshal([]byte(input), &document); err != nil {
+		result := newParseError(ErrIncorrectFormat, input, "cannot parse the camt document")
+		result.Err = err
+		return nil, result
+	}
+	if document.XMLName.Space != "" && !strings.HasPrefix(document.XMLName.Space, camtNamespace+message+".") {
+		return nil, newParseError(ErrUnsupportedMessage, input, "unsupported camt document: %v", document.XMLName.Space)
+	}
+	return &document, nil
+}
+
+func newCamtError(code ErrorCode, element string, value string, format string, args ...interface{}) *ParseError {
+	result := newParseError(code, value, format, args...)
+	result.Tag = element
+	return result
+}
+
+func newStatementsFromCamt(statements []camtStatement, messageType MessageType) ([]Statement, error) {
+	var result []Statement
+	for i, statement := range statements {
+		stmt, err := newStatementFromCamt(statement, messageType)
+		if err != nil {
+			parseError := asParseError(err)
+			parseError.MessageIndex = i + 1
+			return nil, parseError
+		}
+		result = append(result, *stmt)
+	}
+	return result, nil
+}
+
+func newStatementFromCamt(statement camtStatement, messageType MessageType) (*Statement, error) {
+	stmt := Statement{
+		MessageType:           messageType,
+		ReferenceNumber:       newReferenceNumberFromCamt(statement.ID),
+		AccountIdentification: newAccountIdentificationFromCamt(statement.Account),
+		StatementNumber:       newStatementNumberFromCamt(statement),
+		Information:           firstNonEmpty(statement.AdditionalInformation, statement.ReportInformation, statement.NotificationInformation),
+		Encoding:              ENCODING_UTF8,
+	}
+	if messageType == MESSAGE_942 && statement.CreationDateTime != "" {
+		indication, err := newDateTimeIndicationFromCamt(statement.CreationDateTime)
+		if err != nil {
+			return nil, err
+		}
+		stmt.DateTimeIndication = indication
+	}
+
+	for _, balance := range statement.Balances {
+		if err := stmt.setCamtBalance(balance); err != nil {
+			return nil, err
+		}
+	}
+
+	currency := statementCurrency(stmt)
+	if statement.Summary != nil {
+		var err error
+		if stmt.CreditEntries, err = newEntrySummaryFromCamt(statement.Summary.Credit, CREDIT, currency); err != nil {
+			return nil, err
+		}
+		if stmt.DebitEntries, err = newEntrySummaryFromCamt(statement.Summary.Debit, DEBIT, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, entry := range statement.Entries {
+		result, err := newTransactionFromCamt(entry, i+1)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Transactions = append(stmt.Transactions, *result)
+	}
+	return &stmt, nil
+}
+
+func (s *Statement) setCamtBalance(balance camtBalance) error {
+	code := balance.Type.CodeOrProprietary.Code
+	balanceType, ok := camtBalanceTypes[code]
+	if code == "ITBD" {
+		balanceType, ok = INTERMEDIATE_CLOSING, true
+		if s.OpeningBalance.BalanceType == "" {
+			balanceType = INTERMEDIATE_OPENING
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	transactionType, err := newTransactionTypeFromCamt(balance.CreditDebit, false, camtBooked)
+	if err != nil {
+		return err
+	}
+	date, err := newLongDateFromCamt(balance.Date, "Bal/Dt")
+	if err != nil {
+		return err
+	}
+	amount, err := newDecimalFromCamt(balance.Amount.Value, "Bal/Amt")
+	if err != nil {
+		return err
+	}
+	result := Balance{
+		TransactionType: transactionType,
+		Date:            *date,
+		Currency:        balance.Amount.Currency,
+		Amount:          amount,
+		BalanceType:     balanceType,
+	}
+
+	switch balanceType {
+	case OPENING, INTERMEDIATE_OPENING:
+		s.OpeningBalance = result
+	case CLOSING, INTERMEDIATE_CLOSING:
+		s.ClosingBalance = result
+	case AVAILABLE:
+		if s.AvailableBalance == nil {
+			s.AvailableBalance = &result
+		}
+	case FORWARD_AVAILABLE:
+		s.ForwardBalances = append(s.ForwardBalances, result)
+	}
+	return nil
+}
+
+func newTransactionFromCamt(entry camtEntry, index int) (*Transaction, error) {
+	status := strings.TrimSpace(entry.Status.Value)
+	if entry.Status.Code != "" {
+		status = entry.Status.Code
+	}
+	transactionType, err := newTransactionTypeFromCamt(entry.CreditDebit, entry.Reversal, status)
+	if err != nil {
+		return nil, withTransaction(err, index)
+	}
+	amount, err := newDecimalFromCamt(entry.Amount.Value, "Ntry/Amt")
+	if err != nil {
+		return nil, withTransaction(err, index)
+	}
+
+	var valueDate, bookingDate *LongDate
+	if entry.BookingDate != nil {
+		if bookingDate, err = newLongDateFromCamt(*entry.BookingDate, "Ntry/BookgDt"); err != nil {
+			return nil, withTransaction(err, index)
+		}
+	}
+	if entry.ValueDate != nil {
+		if valueDate, err = newLongDateFromCamt(*entry.ValueDate, "Ntry/ValDt"); err != nil {
+			return nil, withTransaction(err, index)
+		}
+	}
+	if valueDate == nil {
+		valueDate = bookingDate
+	}
+	if valueDate == nil {
+		return nil, withTransaction(newCamtError(ErrIncorrectDate, "Ntry/ValDt", "", "the entry has neither a value date nor a booking date"), index)
+	}
+
+	stmt := TransactionStatement{
+		ValueDate:           *valueDate,
+		TransactionType:     transactionType,
+		Amount:              amount,
+		TransactionTypeCode: "NMSC",
+		OwnerReference:      "NONREF",
+		BankReference:       entry.ServicerReference,
+	}
+	if bookingDate != nil {
+		stmt.EntryDate = &ShortDate{Month: bookingDate.Month, Day: bookingDate.Day}
+	}
+	if code := entry.BankTransactionCode.Proprietary; code != nil && camtTransactionCodePattern.MatchString(code.Code) {
+		stmt.TransactionTypeCode = code.Code
+	}
+	details := firstCamtTransactionDetails(entry)
+	if details.References != nil && details.References.EndToEndID != "" && details.References.EndToEndID != "NOTPROVIDED" {
+		stmt.OwnerReference = details.References.EndToEndID
+	}
+	stmt.SupplementaryDetails = details.AdditionalInformation
+
+	return &Transaction{
+		Index:       index,
+		Statement:   stmt,
+		Information: newTransactionInformationFromCamt(entry, details),
+	}, nil
+}
+
+func newTransactionInformationFromCamt(entry camtEntry, details camtTransactionDetails) TransactionInformation {
+	var info TransactionInformation
+	for _, entryDetails := range entry.Details {
+		for _, transaction := range entryDetails.Transactions {
+			if transaction.Remittance != nil {
+				info.Title = append(info.Title, transaction.Remittance.Unstructured...)
+			}
+		}
+	}
+	info.Info = entry.AdditionalInformation
+	if info.Info == "" {
+		info.Info = strings.Join(info.Title, "\n")
+	}
+
+	if details.References != nil {
+		sepa := map[string]string{
+			"EREF": details.References.EndToEndID,
+			"MREF": details.References.MandateID,
+		}
+		for key, value := range sepa {
+			if value == "" {
+				delete(sepa, key)
+			}
+		}
+		if len(sepa) > 0 {
+			info.Sepa = sepa
+		}
+	}
+
+	if parties := details.RelatedParties; parties != nil {
+		party, account := parties.Creditor, parties.CreditorAccount
+		if entry.CreditDebit == camtCredit {
+			party, account = parties.Debtor, parties.DebtorAccount
+		}
+		info.Counterparty = newCounterpartyFromCamt(party, account)
+	}
+	if agents := details.RelatedAgents; agents != nil {
+		agent := agents.CreditorAgent
+		if entry.CreditDebit == camtCredit {
+			agent = agents.DebtorAgent
+		}
+		if agent != nil {
+			info.Counterparty.BIC = firstNonEmpty(agent.FinancialInstitution.BICFI, agent.FinancialInstitution.BIC)
+		}
+	}
+	return info
+}
+
+func newCounterpartyFromCamt(party *camtParty, account *camtAccount) Counterparty {
+	var result Counterparty
+	if party != nil {
+		result.Name = party.Name
+		if party.Party != nil {
+			result.Name = party.Party.Name
+		}
+	}
+	if account != nil {
+		result.IBAN = account.ID.IBAN
+		if account.ID.Other != nil {
+			result.Account = account.ID.Other.ID
+		}
+	}
+	return result
+}
+
+func firstCamtTransactionDetails(entry camtEntry) camtTransactionDetails {
+	for _, details := range entry.Details {
+		if len(details.Transactions) > 0 {
+			return details.Transactions[0]
+		}
+	}
+	return camtTransactionDetails{}
+}
+
+func newReferenceNumberFromCamt(id string) ReferenceNumber {
+	value := truncate(id, maxReferenceNumberLength)
+	for len(value) > maxReferenceNumberLength {
+		_, size := utf8.DecodeLastRuneInString(value)
+		value = value[:len(value)-size]
+	}
+	return ReferenceNumber{Value: value}
+}
+
+func newAccountIdentificationFromCamt(account camtAccount) AccountIdentification {
+	id := account.ID.IBAN
+	if id == "" && account.ID.Other != nil {
+		id = account.ID.Other.ID
+	}
+	if matches := germanAccountPattern.FindStringSubmatch(id); matches != nil {
+		return AccountIdentification{CountryIso: "DE", Iban: id, Currency: account.Currency}
+	}
+	country := GetFirstNChars(id, 2)
+	return AccountIdentification{CountryIso: country, Iban: id[len(country):], Currency: account.Currency}
+}
+
+func newStatementNumberFromCamt(statement camtStatement) StatementNumber {
+	pagination := statement.Pagination
+	if pagination == nil {
+		pagination = statement.ReportPagination
+	}
+	if pagination == nil {
+		pagination = statement.NotificationPagination
+	}
+	if pagination == nil || pagination.PageNumber == "" {
+		return StatementNumber{Value: statement.ElectronicSequenceNumber}
+	}
+	return StatementNumber{Value: statement.ElectronicSequenceNumber + "/" + pagination.PageNumber}
+}
+
+func newEntrySummaryFromCamt(summary *camtNumberAndSum, transactionType TransactionType, currency string) (*EntrySummary, error) {
+	if summary == nil {
+		return nil, nil
+	}
+	result := EntrySummary{TransactionType: transactionType, Currency: currency}
+	if summary.Count != "" {
+		count, err := strconv.ParseInt(summary.Count, 10, 64)
+		if err != nil {
+			parseError := newCamtError(ErrIncorrectFormat, "TxsSummry/NbOfNtries", summary.Count, "incorrect number of entries")
+			parseError.Err = err
+			return nil, parseError
+		}
+		result.Count = count
+	}
+	if summary.Sum != "" {
+		amount, err := newDecimalFromCamt(summary.Sum, "TxsSummry/Sum")
+		if err != nil {
+			return nil, err
+		}
+		result.Amount = amount
+	}
+	return &result, nil
+}
+
+func newTransactionTypeFromCamt(creditDebit string, reversal bool, status string) (TransactionType, error) {
+	var result TransactionType
+	switch creditDebit {
+	case camtCredit:
+		result = CREDIT
+		if reversal {
+			result = REVERSAL_DEBIT
+		} else if status == camtPending {
+			result = EXPECTED_CREDIT
+		}
+	case camtDebit:
+		result = DEBIT
+		if reversal {
+			result = REVERSAL_CREDIT
+		} else if status == camtPending {
+			result = EXPECTED_DEBIT
+		}
+	default:
+		return "", newCamtError(ErrIncorrectType, "CdtDbtInd", creditDebit, "incorrect credit debit indicator: %v", creditDebit)
+	}
+	return result, nil
+}
+
+func newLongDateFromCamt(date camtDate, element string) (*LongDate, error) {
+	value := date.Date
+	if value == "" {
+		value = date.DateTime
+	}
+	if len(value) > len(camtDateFormat) {
+		value = value[:len(camtDateFormat)]
+	}
+	result, err := time.Parse(camtDateFormat, value)
+	if err != nil {
+		parseError := newCamtError(ErrIncorrectDate, element, value, "incorrect date format")
+		parseError.Err = err
+		return nil, parseError
+	}
+	return &LongDate{Year: int64(result.Year() % 100), Month: int64(result.Month()), Day: int64(result.Day())}, nil
+}
+
+func newDateTimeIndicationFromCamt(value string) (*DateTimeIndication, error) {
+	layouts := []string{time.RFC3339, "2006-01-02T15:04:05"}
+	for i, layout := range layouts {
+		result, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		indication := DateTimeIndication{
+			Date:   LongDate{Year: int64(result.Year() % 100), Month: int64(result.Month()), Day: int64(result.Day())},
+			Hour:   int64(result.Hour()),
+			Minute: int64(result.Minute()),
+		}
+		if i == 0 {
+			indication.UTCOffset = result.Format("-0700")
+		}
+		return &indication, nil
+	}
+	return nil, newCamtError(ErrIncorrectDate, "CreDtTm", value, "incorrect date time format")
+}
+
+func newDecimalFromCamt(value string, element string) (MyDecimal, error) {
+	result, err := decimal.NewFromString(strings.TrimSpace(value))
+	if err != nil {
+		parseError := newCamtError(ErrIncorrectAmount, element, value, "incorrect amount format")
+		parseError.Err = err
+		return MyDecimal{}, parseError
+	}
+	if result.IsNegative() {
+		return MyDecimal{}, newCamtError(ErrIncorrectAmount, element, value, "the amount must not be negative")
+	}
+	return MyDecimal(result), nil
+}
@@ -0,0 +1,12 @@
+package mt940_converter
+
+func ParseCamt052(input string) ([]Statement, error) {
+	document, err := parseCamtDocument(input, "camt.052")
+	if err != nil {
+		return nil, err
+	}
+	if document.Report == nil || len(document.Report.Reports) == 0 {
+		return nil, newCamtError(ErrMissingTag, "BkToCstmrAcctRpt/Rpt", input, "the camt.052 document does not contain reports")
+	}
+	return newStatementsFromCamt(document.Report.Reports, MESSAGE_942)
+}
@@ -0,0 +1,290 @@
+package mt940_converter
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const camtDateFormat = "2006-01-02"
+
+var camt053Versions = map[CamtVersion]bool{
+	CAMT_053_001_02: true,
+	CAMT_053_001_08: true,
+}
+
+func ConvertToCamt053(statements []Statement, version CamtVersion, opts ...Option) ([]byte, error) {
+	if !camt053Versions[version] {
+		return nil, newParseError(ErrUnsupportedMessage, string(version), "unsupported camt.053 version: %v", version)
+	}
+	if len(statements) == 0 {
+		return nil, newParseError(ErrMissingTag, "", "no statements to convert")
+	}
+	o := newOptions(opts)
+	createdAt := o.getCreationTime()
+
+	message := camtMessage{
+		GroupHeader: camtGroupHeader{
+			MessageID:        truncate(statements[0].ReferenceNumber.Value, maxCamtIdentifierLength),
+			CreationDateTime: formatCamtDateTime(createdAt),
+		},
+	}
+	for _, stmt := range statements {
+		message.Statements = append(message.Statements, newCamtStatement(stmt, version, createdAt))
+	}
+	return marshalCamt(camtDocument{
+		XMLName:   xml.Name{Space: camtNamespace + string(version), Local: "Document"},
+		Statement: &message,
+	})
+}
+
+func ParseCamt053(input string) ([]Statement, error) {
+	document, err := parseCamtDocument(input, "camt.053")
+	if err != nil {
+		return nil, err
+	}
+	if document.Statement == nil || len(document.Statement.Statements) == 0 {
+		return nil, newCamtError(ErrMissingTag, "BkToCstmrStmt/Stmt", input, "the camt.053 document does not contain statements")
+	}
+	return newStatementsFromCamt(document.Statement.Statements, MESSAGE_940)
+}
+
+func marshalCamt(document camtDocument) ([]byte, error) {
+	result, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), result...), nil
+}
+
+func newCamtStatement(stmt Statement, version CamtVersion, createdAt time.Time) camtStatement {
+	currency := statementCurrency(stmt)
+	result := camtStatement{
+		ID:                       truncate(stmt.ReferenceNumber.Value, maxCamtIdentifierLength),
+		ElectronicSequenceNumber: camtSequenceNumber(stmt.StatementNumber.Number()),
+		CreationDateTime:         formatCamtDateTime(createdAt),
+		Account:                  newCamtAccount(stmt.AccountIdentification, currency),
+		AdditionalInformation:    truncate(stmt.Information, maxCamtInformationLength),
+	}
+	if stmt.DateTimeIndication != nil {
+		result.CreationDateTime = formatCamtDateTime(stmt.DateTimeIndication.Time())
+	}
+	if version.isVersion08() && stmt.StatementNumber.Sequence() != "" {
+		result.Pagination = &camtPagination{
+			PageNumber: camtSequenceNumber(stmt.StatementNumber.Sequence()),
+			LastPage:   stmt.IsLastPage(),
+		}
+	}
+
+	if stmt.OpeningBalance.BalanceType != "" {
+		result.Balances = append(result.Balances, newCamtBalance(stmt.OpeningBalance))
+	}
+	result.Balances = append(result.Balances, newCamtBalance(stmt.ClosingBalance))
+	if stmt.AvailableBalance != nil {
+		result.Balances = append(result.Balances, newCamtBalance(*stmt.AvailableBalance))
+	}
+	for _, balance := range stmt.ForwardBalances {
+		result.Balances = append(result.Balances, newCamtBalance(balance))
+	}
+	result.Summary = newCamtSummary(stmt.DebitEntries, stmt.CreditEntries)
+	for _, entry := range stmt.Transactions {
+		result.Entries = append(result.Entries, newCamtEntry(entry, currency, version))
+	}
+	return result
+}
+
+func newCamtSummary(debit *EntrySummary, credit *EntrySummary) *camtSummary {
+	if debit == nil && credit == nil {
+		return nil
+	}
+	var result camtSummary
+	if credit != nil {
+		result.Credit = newCamtNumberAndSum(*credit)
+	}
+	if debit != nil {
+		result.Debit = newCamtNumberAndSum(*debit)
+	}
+	return &result
+}
+
+func newCamtNumberAndSum(summary EntrySummary) *camtNumberAndSum {
+	return &camtNumberAndSum{
+		Count: strconv.FormatInt(summary.Count, 10),
+		Sum:   newCamtAmount(summary.Amount, summary.Currency).Value,
+	}
+}
+
+func newCamtBalance(balance Balance) camtBalance {
+	return camtBalance{
+		Type:        camtBalanceType{CodeOrProprietary: camtCode{Code: camtBalanceCodes[balance.BalanceType]}},
+		Amount:      newCamtAmount(balance.Amount, balance.Currency),
+		CreditDebit: camtCreditDebit(balance.TransactionType),
+		Date:        camtDate{Date: formatCamtDate(balance.Date)},
+	}
+}
+
+func newCamtEntry(entry Transaction, currency string, version CamtVersion) camtEntry {
+	stmt := entry.Statement
+	status := camtBooked
+	if stmt.TransactionType.IsExpected() {
+		status = camtPending
+	}
+	result := camtEntry{
+		Amount:            newCamtAmount(stmt.Amount, currency),
+		CreditDebit:       camtCreditDebit(stmt.TransactionType),
+		Reversal:          stmt.TransactionType.IsReversal(),
+		Status:            newCamtStatus(status, version),
+		BookingDate:       &camtDate{Date: formatCamtDate(stmt.BookingDate())},
+		ValueDate:         &camtDate{Date: formatCamtDate(stmt.ValueDate)},
+		ServicerReference: truncate(stmt.BankReference, maxCamtIdentifierLength),
+		BankTransactionCode: camtBankTransactionCode{
+			Proprietary: &camtProprietaryCode{Code: stmt.TransactionTypeCode, Issuer: camtIssuer},
+		},
+		AdditionalInformation: truncate(entry.Information.Info, maxCamtInformationLength),
+	}
+	if details := newCamtTransactionDetails(entry, version); details != nil {
+		result.Details = []camtEntryDetails{{Transactions: []camtTransactionDetails{*details}}}
+	}
+	return result
+}
+
+func newCamtTransactionDetails(entry Transaction, version CamtVersion) *camtTransactionDetails {
+	info := entry.Information
+	var result camtTransactionDetails
+	empty := true
+
+	references := camtReferences{
+		EndToEndID: truncate(info.Sepa["EREF"], maxCamtIdentifierLength),
+		MandateID:  truncate(info.Sepa["MREF"], maxCamtIdentifierLength),
+	}
+	if references.EndToEndID == "" && entry.Statement.OwnerReference != "NONREF" {
+		references.EndToEndID = truncate(entry.Statement.OwnerReference, maxCamtIdentifierLength)
+	}
+	if references != (camtReferences{}) {
+		result.References = &references
+		empty = false
+	}
+
+	if info.Counterparty.Name != "" || info.Counterparty.IBAN != "" {
+		var party *camtParty
+		if info.Counterparty.Name != "" {
+			party = newCamtParty(info.Counterparty.Name, version)
+		}
+		var account *camtAccount
+		if info.Counterparty.IBAN != "" {
+			account = &camtAccount{ID: newCamtAccountID(info.Counterparty.IBAN)}
+		}
+		if entry.Statement.TransactionType.Sign() > 0 {
+			result.RelatedParties = &camtRelatedParties{Debtor: party, DebtorAccount: account}
+		} else {
+			result.RelatedParties = &camtRelatedParties{Creditor: party, CreditorAccount: account}
+		}
+		empty = false
+	}
+	if camtBICPattern.MatchString(info.Counterparty.BIC) {
+		agent := newCamtAgent(info.Counterparty.BIC, version)
+		if entry.Statement.TransactionType.Sign() > 0 {
+			result.RelatedAgents = &camtRelatedAgents{DebtorAgent: agent}
+		} else {
+			result.RelatedAgents = &camtRelatedAgents{CreditorAgent: agent}
+		}
+		empty = false
+	}
+
+	if remittance := splitText(remittanceText(info), maxCamtRemittanceLength); len(remittance) > 0 {
+		result.Remittance = &camtRemittance{Unstructured: remittance}
+		empty = false
+	}
+	if entry.Statement.SupplementaryDetails != "" {
+		result.AdditionalInformation = truncate(entry.Statement.SupplementaryDetails, maxCamtInformationLength)
+		empty = false
+	}
+
+	if empty {
+		return nil
+	}
+	return &result
+}
+
+func remittanceText(info TransactionInformation) string {
+	if info.Sepa["SVWZ"] != "" {
+		return info.Sepa["SVWZ"]
+	}
+	if len(info.Title) > 0 {
+		return strings.Join(info.Title, " ")
+	}
+	return info.Info
+}
+
+func newCamtParty(name string, version CamtVersion) *camtParty {
+	party := &camtParty{Name: truncate(name, maxCamtNameLength)}
+	if version.isVersion08() {
+		return &camtParty{Party: party}
+	}
+	return party
+}
+
+func newCamtAgent(bic string, version CamtVersion) *camtAgent {
+	if version.isVersion08() {
+		return &camtAgent{FinancialInstitution: camtFinancialInstitution{BICFI: bic}}
+	}
+	return &camtAgent{FinancialInstitution: camtFinancialInstitution{BIC: bic}}
+}
+
+func newCamtStatus(status string, version CamtVersion) camtStatus {
+	if version.isVersion08() {
+		return camtStatus{Code: status}
+	}
+	return camtStatus{Value: status}
+}
+
+func newCamtAmount(amount MyDecimal, currency string) camtAmount {
+	value := decimal.Decimal(amount).Abs()
+	places := -value.Exponent()
+	if places < 0 {
+		places = 0
+	}
+	return camtAmount{Currency: currency, Value: value.StringFixed(places)}
+}
+
+func camtCreditDebit(transactionType TransactionType) string {
+	if transactionType.Sign() > 0 {
+		return camtCredit
+	}
+	return camtDebit
+}
+
+func camtSequenceNumber(number string) string {
+	if number == "" || !isNumeric(number) {
+		return ""
+	}
+	if result := strings.TrimLeft(number, "0"); result != "" {
+		return result
+	}
+	return "0"
+}
+
+func accountNumber(account AccountIdentification) string {
+	return strings.TrimSuffix(FormatAccountIdentification(account), account.Currency)
+}
+
+func statementCurrency(stmt Statement) string {
+	if stmt.AccountIdentification.Currency != "" {
+		return stmt.AccountIdentification.Currency
+	}
+	if stmt.OpeningBalance.Currency != "" {
+		return stmt.OpeningBalance.Currency
+	}
+	return stmt.ClosingBalance.Currency
+}
+
+func formatCamtDate(date LongDate) string {
+	return date.Time().Format(camtDateFormat)
+}
+
+func formatCamtDateTime(value time.Time) string {
+	return value.Format(time.RFC3339)
+}
@@ -0,0 +1,85 @@
+package mt940_converter
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+var camt054Versions = map[CamtVersion]bool{
+	CAMT_054_001_02: true,
+	CAMT_054_001_08: true,
+}
+
+func ParseCamt054(input string) ([]Transaction, error) {
+	document, err := parseCamtDocument(input, "camt.054")
+	if err != nil {
+		return nil, err
+	}
+	if document.Notification == nil || len(document.Notification.Notifications) == 0 {
+		return nil, newCamtError(ErrMissingTag, "BkToCstmrDbtCdtNtfctn/Ntfctn", input, "the camt.054 document does not contain notifications")
+	}
+
+	result := []Transaction{}
+	for i, notification := range document.Notification.Notifications {
+		for _, entry := range notification.Entries {
+			transaction, err := newTransactionFromCamt(entry, len(result)+1)
+			if err != nil {
+				parseError := asParseError(err)
+				parseError.MessageIndex = i + 1
+				return nil, parseError
+			}
+			result = append(result, *transaction)
+		}
+	}
+	return result, nil
+}
+
+func ConvertToCamt054(reports []MT942, version CamtVersion, opts ...Option) ([]byte, error) {
+	if !camt054Versions[version] {
+		return nil, newParseError(ErrUnsupportedMessage, string(version), "unsupported camt.054 version: %v", version)
+	}
+	if len(reports) == 0 {
+		return nil, newParseError(ErrMissingTag, "", "no reports to convert")
+	}
+	o := newOptions(opts)
+	createdAt := o.getCreationTime()
+
+	message := camtMessage{
+		GroupHeader: camtGroupHeader{
+			MessageID:        truncate(reports[0].ReferenceNumber.Value, maxCamtIdentifierLength),
+			CreationDateTime: formatCamtDateTime(createdAt),
+		},
+	}
+	for _, report := range reports {
+		message.Notifications = append(message.Notifications, newCamtNotification(report, version, createdAt))
+	}
+	return marshalCamt(camtDocument{
+		XMLName:      xml.Name{Space: camtNamespace + string(version), Local: "Document"},
+		Notification: &message,
+	})
+}
+
+func newCamtNotification(report MT942, version CamtVersion, createdAt time.Time) camtStatement {
+	currency := firstNonEmpty(report.AccountIdentification.Currency, report.CreditFloorLimit.Currency, report.DebitFloorLimit.Currency)
+	result := camtStatement{
+		ID:                       truncate(report.ReferenceNumber.Value, maxCamtIdentifierLength),
+		ElectronicSequenceNumber: camtSequenceNumber(report.StatementNumber.Number()),
+		CreationDateTime:         formatCamtDateTime(createdAt),
+		Account:                  newCamtAccount(report.AccountIdentification, currency),
+		Summary:                  newCamtSummary(report.DebitEntries, report.CreditEntries),
+		NotificationInformation:  truncate(report.Information, maxCamtInformationLength),
+	}
+	if report.DateTimeIndication != (DateTimeIndication{}) {
+		result.CreationDateTime = formatCamtDateTime(report.DateTimeIndication.Time())
+	}
+	if version.isVersion08() && report.StatementNumber.Sequence() != "" {
+		result.NotificationPagination = &camtPagination{
+			PageNumber: camtSequenceNumber(report.StatementNumber.Sequence()),
+			LastPage:   true,
+		}
+	}
+	for _, entry := range report.Transactions {
+		result.Entries = append(result.Entries, newCamtEntry(entry, currency, version))
+	}
+	return result
+}
@@ -0,0 +1,764 @@
+package mt940_converter
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var camtCreationTime = time.Date(2023, 6, 3, 12, 15, 0, 0, time.UTC)
+
+const germanCamt053Output = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <GrpHdr>
+      <MsgId>REF</MsgId>
+      <CreDtTm>2023-06-03T12:15:00Z</CreDtTm>
+    </GrpHdr>
+    <Stmt>
+      <Id>REF</Id>
+      <ElctrncSeqNb>1</ElctrncSeqNb>
+      <CreDtTm>2023-06-03T12:15:00Z</CreDtTm>
+      <Acct>
+        <Id>
+          <Othr>
+            <Id>37040044/0532013000</Id>
+          </Othr>
+        </Id>
+        <Ccy>EUR</Ccy>
+      </Acct>
+      <Bal>
+        <Tp>
+          <CdOrPrtry>
+            <Cd>OPBD</Cd>
+          </CdOrPrtry>
+        </Tp>
+        <Amt Ccy="EUR">1000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt>
+          <Dt>2023-06-01</Dt>
+        </Dt>
+      </Bal>
+      <Bal>
+        <Tp>
+          <CdOrPrtry>
+            <Cd>CLBD</Cd>
+          </CdOrPrtry>
+        </Tp>
+        <Amt Ccy="EUR">1100.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt>
+          <Dt>2023-06-02</Dt>
+        </Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="EUR">100</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt>
+          <Dt>2023-06-02</Dt>
+        </BookgDt>
+        <ValDt>
+          <Dt>2023-06-02</Dt>
+        </ValDt>
+        <BkTxCd>
+          <Prtry>
+            <Cd>NTRF</Cd>
+            <Issr>SWIFT</Issr>
+          </Prtry>
+        </BkTxCd>
+        <NtryDtls>
+          <TxDtls>
+            <Refs>
+              <EndToEndId>E2E-1</EndToEndId>
+            </Refs>
+            <RltdPties>
+              <Dbtr>
+                <Nm>Max Mustermann</Nm>
+              </Dbtr>
+            </RltdPties>
+            <RmtInf>
+              <Ustrd>Miete</Ustrd>
+            </RmtInf>
+          </TxDtls>
+        </NtryDtls>
+        <AddtlNtryInf>166?00SEPA-GUTSCHRIFT?20EREF+E2E-1?21SVWZ+Miete?32Max Mustermann</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestConvertToCamt053Case(t *testing.T) {
+	stmt, err := ParseStatement(germanStatementInput)
+	assert.Nil(t, err)
+
+	actual, err := ConvertToCamt053([]Statement{*stmt}, CAMT_053_001_02, WithCreationTime(camtCreationTime))
+	assert.Nil(t, err)
+	assert.Equal(t, germanCamt053Output, string(actual))
+}
+
+func TestConvertToCamt053VersionCase(t *testing.T) {
+	type testCase struct {
+		name     string
+		version  CamtVersion
+		expected []string
+	}
+
+	testTable := []testCase{
+		{
+			name:    "camt.053.001.02",
+			version: CAMT_053_001_02,
+			expected: []string{
+				`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">`,
+				"<Sts>BOOK</Sts>",
+				"<Cdtr>\n                <Nm>",
+			},
+		},
+		{
+			name:    "camt.053.001.08",
+			version: CAMT_053_001_08,
+			expected: []string{
+				`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">`,
+				"<StmtPgntn>\n        <PgNb>1</PgNb>\n        <LastPgInd>true</LastPgInd>\n      </StmtPgntn>",
+				"<Sts>\n          <Cd>BOOK</Cd>\n        </Sts>",
+				"<Cdtr>\n                <Pty>\n                  <Nm>",
+			},
+		},
+	}
+
+	stmt, err := ParseStatement(statementInput)
+	assert.Nil(t, err)
+	stmt.Transactions[0].Information.Counterparty.Name = "Bank"
+
+	for _, test := range testTable {
+		actual, err := ConvertToCamt053([]Statement{*stmt}, test.version, WithCreationTime(camtCreationTime))
+		assert.Nil(t, err, test.name)
+		for _, expected := range test.expected {
+			assert.Contains(t, string(actual), expected, test.name)
+		}
+		assert.Nil(t, xml.Unmarshal(actual, new(camtDocument)), test.name)
+	}
+}
+
+func TestConvertToCamt053MappingCase(t *testing.T) {
+	stmt, err := ParseStatement(statementInput)
+	assert.Nil(t, err)
+
+	output, err := ConvertToCamt053([]Statement{*stmt}, CAMT_053_001_02, WithCreationTime(camtCreationTime))
+	assert.Nil(t, err)
+	var document camtDocument
+	assert.Nil(t, xml.Unmarshal(output, &document))
+
+	actual := document.Statement.Statements[0]
+	assert.Equal(t, "STARTUMS", document.Statement.GroupHeader.MessageID)
+	assert.Equal(t, "NL17RABO6064103256", actual.Account.ID.IBAN)
+	assert.Equal(t, "EUR", actual.Account.Currency)
+	assert.Equal(t, "Statement information", actual.AdditionalInformation)
+
+	var codes []string
+	for _, balance := range actual.Balances {
+		codes = append(codes, balance.Type.CodeOrProprietary.Code)
+	}
+	assert.Equal(t, []string{"OPBD", "CLBD", "CLAV"}, codes)
+
+	assert.Len(t, actual.Entries, 2)
+	debit := actual.Entries[0]
+	assert.Equal(t, camtAmount{Currency: "EUR", Value: "2.50"}, debit.Amount)
+	assert.Equal(t, "DBIT", debit.CreditDebit)
+	assert.Equal(t, "BR07282102000059", debit.ServicerReference)
+	assert.Equal(t, "NCHG", debit.BankTransactionCode.Proprietary.Code)
+	assert.Nil(t, debit.Details[0].Transactions[0].References)
+	assert.Equal(t, "824-OPŁ. ZA PRZEL. ELIXIR MT", debit.Details[0].Transactions[0].AdditionalInformation)
+
+	credit := actual.Entries[1]
+	assert.Equal(t, "CRDT", credit.CreditDebit)
+	assert.Equal(t, "2023-06-03", credit.BookingDate.Date)
+	assert.Equal(t, "2023-06-03", credit.ValueDate.Date)
+	assert.Equal(t, "SP300", credit.Details[0].Transactions[0].References.EndToEndID)
+	assert.Equal(t, []string{"944 Przelew krajowy tyt.: fv 100/2007"}, credit.Details[0].Transactions[0].Remittance.Unstructured)
+}
+
+func TestConvertToCamt053EntryCase(t *testing.T) {
+	type testCase struct {
+		name        string
+		input       string
+		creditDebit string
+		reversal    bool
+		status      string
+		bookingDate string
+	}
+
+	testTable := []testCase{
+		{name: "Reversal of credit", input: "2306020602RCN100,NTRFNONREF", creditDebit: "DBIT", reversal: true, status: "BOOK", bookingDate: "2023-06-02"},
+		{name: "Reversal of debit", input: "2306020602RDN100,NTRFNONREF", creditDebit: "CRDT", reversal: true, status: "BOOK", bookingDate: "2023-06-02"},
+		{name: "Expected credit", input: "230602ECN100,NTRFNONREF", creditDebit: "CRDT", status: "PDNG", bookingDate: "2023-06-02"},
+		{name: "Entry date in the next year", input: "2312310102DN100,NTRFNONREF", creditDebit: "DBIT", status: "BOOK", bookingDate: "2024-01-02"},
+	}
+
+	for _, test := range testTable {
+		stmt, err := GetStatement(test.input)
+		assert.Nil(t, err, test.name)
+
+		actual := newCamtEntry(Transaction{Statement: *stmt}, "EUR", CAMT_053_001_02)
+		assert.Equal(t, test.creditDebit, actual.CreditDebit, test.name)
+		assert.Equal(t, test.reversal, actual.Reversal, test.name)
+		assert.Equal(t, test.status, actual.Status.Value, test.name)
+		assert.Equal(t, test.bookingDate, actual.BookingDate.Date, test.name)
+		assert.Nil(t, actual.Details, test.name)
+	}
+}
+
+func TestConvertToCamt053RemittanceCase(t *testing.T) {
+	stmt, err := ParseStatement(secondStatementInput)
+	assert.Nil(t, err)
+	stmt.Transactions = []Transaction{{
+		Statement:   TransactionStatement{TransactionType: CREDIT, TransactionTypeCode: "NTRF", OwnerReference: "NONREF"},
+		Information: TransactionInformation{Info: strings.Repeat("A", 150) + "\n" + strings.Repeat("B", 400)},
+	}}
+
+	output, err := ConvertToCamt053([]Statement{*stmt}, CAMT_053_001_02, WithCreationTime(camtCreationTime))
+	assert.Nil(t, err)
+	var document camtDocument
+	assert.Nil(t, xml.Unmarshal(output, &document))
+
+	entry := document.Statement.Statements[0].Entries[0]
+	remittance := entry.Details[0].Transactions[0].Remittance.Unstructured
+	assert.Len(t, remittance, 4)
+	for _, line := range remittance {
+		assert.LessOrEqual(t, len(line), maxCamtRemittanceLength)
+	}
+	assert.Equal(t, strings.Repeat("A", 140), remittance[0])
+	assert.Equal(t, strings.Repeat("A", 10)+" "+strings.Repeat("B", 129), remittance[1])
+	assert.Len(t, entry.AdditionalInformation, maxCamtInformationLength)
+}
+
+func TestConvertToCamt053AccountCase(t *testing.T) {
+	account := "NL" + strings.Repeat("1", 33)
+	stmt, err := ParseStatement(strings.Replace(germanStatementInput, ":25:37040044/0532013000EUR", ":25:"+account, 1))
+	assert.Nil(t, err)
+
+	output, err := ConvertToCamt053([]Statement{*stmt}, CAMT_053_001_02, WithCreationTime(camtCreationTime))
+	assert.Nil(t, err)
+	var document camtDocument
+	assert.Nil(t, xml.Unmarshal(output, &document))
+
+	actual := document.Statement.Statements[0].Account
+	assert.Empty(t, actual.ID.IBAN)
+	assert.Equal(t, account[:34], actual.ID.Other.ID)
+}
+
+func TestConvertToCamt053ErrorCase(t *testing.T) {
+	stmt, err := ParseStatement(germanStatementInput)
+	assert.Nil(t, err)
+
+	_, err = ConvertToCamt053([]Statement{*stmt}, "camt.053.001.99")
+	assert.True(t, errors.Is(err, ErrUnsupportedMessage))
+
+	_, err = ConvertToCamt053(nil, CAMT_053_001_02)
+	assert.True(t, errors.Is(err, ErrMissingTag))
+}
+
+func TestDateTimeIndicationTimeCase(t *testing.T) {
+	indication, err := GetDateTimeIndication(":13D:2306031215+0200\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-06-03T12:15:00+02:00", indication.Time().Format(time.RFC3339))
+
+	indication, err = GetDateTimeIndication(":13D:2306031215-0130\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-06-03T12:15:00-01:30", indication.Time().Format(time.RFC3339))
+}
+
+const camt052Input = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.052.001.08">
+  <BkToCstmrAcctRpt>
+    <GrpHdr>
+      <MsgId>RPT-20230603</MsgId>
+      <CreDtTm>2023-06-03T12:15:00+02:00</CreDtTm>
+    </GrpHdr>
+    <Rpt>
+      <Id>RPT-1</Id>
+      <RptPgntn>
+        <PgNb>2</PgNb>
+        <LastPgInd>true</LastPgInd>
+      </RptPgntn>
+      <ElctrncSeqNb>12</ElctrncSeqNb>
+      <CreDtTm>2023-06-03T12:15:00+02:00</CreDtTm>
+      <Acct>
+        <Id>
+          <IBAN>PL61109010140000071219812874</IBAN>
+        </Id>
+        <Ccy>PLN</Ccy>
+      </Acct>
+      <Bal>
+        <Tp>
+          <CdOrPrtry>
+            <Cd>ITBD</Cd>
+          </CdOrPrtry>
+        </Tp>
+        <Amt Ccy="PLN">500.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Dt>
+          <DtTm>2023-06-03T08:00:00</DtTm>
+        </Dt>
+      </Bal>
+      <Bal>
+        <Tp>
+          <CdOrPrtry>
+            <Cd>ITBD</Cd>
+          </CdOrPrtry>
+        </Tp>
+        <Amt Ccy="PLN">250.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt>
+          <DtTm>2023-06-03T12:00:00</DtTm>
+        </Dt>
+      </Bal>
+      <TxsSummry>
+        <TtlCdtNtries>
+          <NbOfNtries>1</NbOfNtries>
+          <Sum>750.00</Sum>
+        </TtlCdtNtries>
+      </TxsSummry>
+      <Ntry>
+        <Amt Ccy="PLN">750.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>
+          <Cd>PDNG</Cd>
+        </Sts>
+        <BookgDt>
+          <DtTm>2023-06-03T10:30:00</DtTm>
+        </BookgDt>
+        <ValDt>
+          <Dt>2023-06-03</Dt>
+        </ValDt>
+        <AcctSvcrRef>BR2023060301</AcctSvcrRef>
+        <BkTxCd>
+          <Domn>
+            <Cd>PMNT</Cd>
+            <Fmly>
+              <Cd>RCDT</Cd>
+              <SubFmlyCd>ESCT</SubFmlyCd>
+            </Fmly>
+          </Domn>
+        </BkTxCd>
+        <NtryDtls>
+          <TxDtls>
+            <Refs>
+              <EndToEndId>NOTPROVIDED</EndToEndId>
+            </Refs>
+            <RltdPties>
+              <Dbtr>
+                <Pty>
+                  <Nm>Jan Kowalski</Nm>
+                </Pty>
+              </Dbtr>
+              <DbtrAcct>
+                <Id>
+                  <IBAN>PL27114020040000300201355387</IBAN>
+                </Id>
+              </DbtrAcct>
+            </RltdPties>
+            <RmtInf>
+              <Ustrd>Faktura 100/2023</Ustrd>
+            </RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+    </Rpt>
+  </BkToCstmrAcctRpt>
+</Document>`
+
+func TestParseCamt052Case(t *testing.T) {
+	opening, _ := GetDecimal("500,00")
+	closing, _ := GetDecimal("250,00")
+	amount, _ := GetDecimal("750,00")
+
+	expected := []Statement{{
+		MessageType:           MESSAGE_942,
+		ReferenceNumber:       ReferenceNumber{Value: "RPT-1"},
+		AccountIdentification: AccountIdentification{CountryIso: "PL", Iban: "61109010140000071219812874", Currency: "PLN"},
+		StatementNumber:       StatementNumber{Value: "12/2"},
+		DateTimeIndication:    &DateTimeIndication{Date: LongDate{Year: 23, Month: 6, Day: 3}, Hour: 12, Minute: 15, UTCOffset: "+0200"},
+		OpeningBalance: Balance{
+			TransactionType: DEBIT,
+			Date:            LongDate{Year: 23, Month: 6, Day: 3},
+			Currency:        "PLN",
+			Amount:          opening,
+			BalanceType:     INTERMEDIATE_OPENING,
+		},
+		ClosingBalance: Balance{
+			TransactionType: CREDIT,
+			Date:            LongDate{Year: 23, Month: 6, Day: 3},
+			Currency:        "PLN",
+			Amount:          closing,
+			BalanceType:     INTERMEDIATE_CLOSING,
+		},
+		CreditEntries: &EntrySummary{TransactionType: CREDIT, Count: 1, Currency: "PLN", Amount: amount},
+		Transactions: []Transaction{{
+			Index: 1,
+			Statement: TransactionStatement{
+				ValueDate:           LongDate{Year: 23, Month: 6, Day: 3},
+				EntryDate:           &ShortDate{Month: 6, Day: 3},
+				TransactionType:     EXPECTED_CREDIT,
+				Amount:              amount,
+				TransactionTypeCode: "NMSC",
+				OwnerReference:      "NONREF",
+				BankReference:       "BR2023060301",
+			},
+			Information: TransactionInformation{
+				Info:         "Faktura 100/2023",
+				Title:        []string{"Faktura 100/2023"},
+				Counterparty: Counterparty{Name: "Jan Kowalski", IBAN: "PL27114020040000300201355387"},
+				Sepa:         map[string]string{"EREF": "NOTPROVIDED"},
+			},
+		}},
+		Encoding: ENCODING_UTF8,
+	}}
+
+	actual, err := ParseCamt052(camt052Input)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+	assert.False(t, actual[0].IsFirstPage())
+	assert.False(t, actual[0].IsLastPage())
+}
+
+func TestCamtToMT940RoundTripCase(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		parse func(string) ([]Statement, error)
+	}
+
+	testTable := []testCase{
+		{name: "camt.053", input: germanCamt053Output, parse: ParseCamt053},
+		{name: "camt.052", input: camt052Input, parse: ParseCamt052},
+	}
+
+	for _, test := range testTable {
+		statements, err := test.parse(test.input)
+		assert.Nil(t, err, test.name)
+
+		for _, expected := range statements {
+			expected.MessageType = MESSAGE_940
+			var output strings.Builder
+			assert.Nil(t, NewEncoder(&output).Encode(&expected), test.name)
+			assert.NotContains(t, output.String(), dateTimeIndication, test.name)
+
+			actual, err := ParseStatement(output.String())
+			assert.Nil(t, err, test.name)
+			assert.Equal(t, MessageType(MESSAGE_940), actual.MessageType, test.name)
+			assert.Equal(t, expected.ReferenceNumber, actual.ReferenceNumber, test.name)
+			assert.Equal(t, expected.AccountIdentification, actual.AccountIdentification, test.name)
+			assert.Equal(t, expected.StatementNumber, actual.StatementNumber, test.name)
+			assert.Equal(t, expected.OpeningBalance, actual.OpeningBalance, test.name)
+			assert.Equal(t, expected.ClosingBalance, actual.ClosingBalance, test.name)
+			assert.Len(t, actual.Transactions, len(expected.Transactions), test.name)
+			for i, transaction := range expected.Transactions {
+				assert.Equal(t, transaction.Statement, actual.Transactions[i].Statement, test.name)
+				assert.Equal(t, transaction.Information.Info, actual.Transactions[i].Information.Info, test.name)
+			}
+		}
+	}
+}
+
+func TestParseCamt053RoundTripCase(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+	}
+
+	testTable := []testCase{
+		{name: "Statement", input: statementInput},
+		{name: "German statement", input: germanStatementInput},
+		{name: "First page", input: firstPageInput},
+		{name: "Second page", input: secondPageInput},
+	}
+
+	for _, test := range testTable {
+		for _, version := range []CamtVersion{CAMT_053_001_02, CAMT_053_001_08} {
+			name := test.name + " " + string(version)
+			expected, err := ParseStatement(test.input)
+			assert.Nil(t, err, name)
+
+			output, err := ConvertToCamt053([]Statement{*expected}, version, WithCreationTime(camtCreationTime))
+			assert.Nil(t, err, name)
+			statements, err := ParseCamt053(string(output))
+			assert.Nil(t, err, name)
+			assert.Len(t, statements, 1, name)
+
+			actual := statements[0]
+			assert.Equal(t, expected.ReferenceNumber, actual.ReferenceNumber, name)
+			assert.Equal(t, expected.AccountIdentification, actual.AccountIdentification, name)
+			assert.Equal(t, expected.OpeningBalance, actual.OpeningBalance, name)
+			assert.Equal(t, expected.ClosingBalance, actual.ClosingBalance, name)
+			assert.Equal(t, expected.AvailableBalance, actual.AvailableBalance, name)
+			assert.Equal(t, expected.Information, actual.Information, name)
+			assert.Equal(t, expected.IsLastPage(), actual.IsLastPage(), name)
+			assert.Len(t, actual.Transactions, len(expected.Transactions), name)
+			for i, transaction := range expected.Transactions {
+				transaction.Statement.FundsCode = ""
+				transaction.Statement.OwnerReference = actual.Transactions[i].Statement.OwnerReference
+				assert.Equal(t, transaction.Statement, actual.Transactions[i].Statement, name)
+				assert.Equal(t, transaction.Information.Info, actual.Transactions[i].Information.Info, name)
+				assert.Equal(t, transaction.Information.Counterparty.Name, actual.Transactions[i].Information.Counterparty.Name, name)
+			}
+		}
+	}
+}
+
+func TestParseCamt053ReferenceCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		id             string
+		expectedResult string
+	}
+
+	testTable := []testCase{
+		{name: "Short identifier", id: "REF", expectedResult: "REF"},
+		{name: "Identifier of 35 characters", id: strings.Repeat("A", 35), expectedResult: strings.Repeat("A", 16)},
+		{name: "Identifier with multi-byte characters", id: strings.Repeat("Ä", 10), expectedResult: strings.Repeat("Ä", 8)},
+	}
+
+	for _, test := range testTable {
+		input := strings.Replace(germanCamt053Output, "<Id>REF</Id>", "<Id>"+test.id+"</Id>", 1)
+		statements, err := ParseCamt053(input)
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.expectedResult, statements[0].ReferenceNumber.Value, test.name)
+		assert.Nil(t, statements[0].DateTimeIndication, test.name)
+
+		var output strings.Builder
+		assert.Nil(t, NewEncoder(&output).Encode(&statements[0]), test.name)
+		actual, err := ParseStatement(output.String())
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, statements[0].ReferenceNumber, actual.ReferenceNumber, test.name)
+	}
+}
+
+func TestParseCamt053ErrorCase(t *testing.T) {
+	type testCase struct {
+		name        string
+		input       string
+		code        ErrorCode
+		transaction int
+	}
+
+	stmt, err := ParseStatement(germanStatementInput)
+	assert.Nil(t, err)
+	output, err := ConvertToCamt053([]Statement{*stmt}, CAMT_053_001_02, WithCreationTime(camtCreationTime))
+	assert.Nil(t, err)
+	document := string(output)
+
+	testTable := []testCase{
+		{name: "Not XML", input: ":20:REF\r\n", code: ErrIncorrectFormat},
+		{name: "Other message", input: strings.Replace(document, "camt.053.001.02", "camt.054.001.02", 1), code: ErrUnsupportedMessage},
+		{name: "No statements", input: `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"/>`, code: ErrMissingTag},
+		{name: "Incorrect balance date", input: strings.Replace(document, "2023-06-01", "2023-13-01", 1), code: ErrIncorrectDate},
+		{name: "Incorrect entry amount", input: strings.Replace(document, `<Amt Ccy="EUR">100</Amt>`, `<Amt Ccy="EUR">1,00</Amt>`, 1), code: ErrIncorrectAmount, transaction: 1},
+		{name: "Negative entry amount", input: strings.Replace(document, `<Amt Ccy="EUR">100</Amt>`, `<Amt Ccy="EUR">-100</Amt>`, 1), code: ErrIncorrectAmount, transaction: 1},
+		{name: "Incorrect credit debit indicator", input: strings.Replace(document, "<CdtDbtInd>CRDT</CdtDbtInd>\n        <Sts>", "<CdtDbtInd>CR</CdtDbtInd>\n        <Sts>", 1), code: ErrIncorrectType, transaction: 1},
+	}
+
+	for _, test := range testTable {
+		actual, err := ParseCamt053(test.input)
+		assert.Nil(t, actual, test.name)
+		assert.True(t, errors.Is(err, test.code), test.name)
+		if test.transaction > 0 {
+			var parseError *ParseError
+			assert.True(t, errors.As(err, &parseError), test.name)
+			assert.Equal(t, 1, parseError.MessageIndex, test.name)
+			assert.Equal(t, test.transaction, parseError.Transaction, test.name)
+		}
+	}
+
+	_, err = ParseCamt052(document)
+	assert.True(t, errors.Is(err, ErrUnsupportedMessage))
+}
+
+const camt054Input = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.054.001.02">
+  <BkToCstmrDbtCdtNtfctn>
+    <GrpHdr>
+      <MsgId>NTF-20230603</MsgId>
+      <CreDtTm>2023-06-03T12:15:00</CreDtTm>
+    </GrpHdr>
+    <Ntfctn>
+      <Id>NTF-1</Id>
+      <CreDtTm>2023-06-03T12:15:00</CreDtTm>
+      <Acct>
+        <Id>
+          <IBAN>DE89370400440532013000</IBAN>
+        </Id>
+      </Acct>
+      <Ntry>
+        <Amt Ccy="EUR">1250.5</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt>
+          <Dt>2023-06-03</Dt>
+        </BookgDt>
+        <ValDt>
+          <Dt>2023-06-02</Dt>
+        </ValDt>
+        <AcctSvcrRef>2023060300001</AcctSvcrRef>
+        <BkTxCd>
+          <Prtry>
+            <Cd>NTRF</Cd>
+            <Issr>SWIFT</Issr>
+          </Prtry>
+        </BkTxCd>
+        <NtryDtls>
+          <TxDtls>
+            <Refs>
+              <EndToEndId>INV-2023-17</EndToEndId>
+              <MndtId>MANDATE-1</MndtId>
+            </Refs>
+            <RltdPties>
+              <Dbtr>
+                <Nm>ACME GmbH</Nm>
+              </Dbtr>
+              <DbtrAcct>
+                <Id>
+                  <IBAN>NL91ABNA0417164300</IBAN>
+                </Id>
+              </DbtrAcct>
+            </RltdPties>
+            <RltdAgts>
+              <DbtrAgt>
+                <FinInstnId>
+                  <BIC>ABNANL2A</BIC>
+                </FinInstnId>
+              </DbtrAgt>
+            </RltdAgts>
+            <RmtInf>
+              <Ustrd>Invoice 2023-17</Ustrd>
+            </RmtInf>
+            <AddtlTxInf>SEPA credit transfer</AddtlTxInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+    </Ntfctn>
+    <Ntfctn>
+      <Id>NTF-2</Id>
+      <CreDtTm>2023-06-03T12:20:00</CreDtTm>
+      <Acct>
+        <Id>
+          <IBAN>DE89370400440532013000</IBAN>
+        </Id>
+      </Acct>
+      <Ntry>
+        <Amt Ccy="EUR">80.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <RvslInd>true</RvslInd>
+        <Sts>BOOK</Sts>
+        <BookgDt>
+          <Dt>2023-06-03</Dt>
+        </BookgDt>
+        <BkTxCd>
+          <Prtry>
+            <Cd>Reversal</Cd>
+          </Prtry>
+        </BkTxCd>
+        <AddtlNtryInf>Returned direct debit</AddtlNtryInf>
+      </Ntry>
+    </Ntfctn>
+  </BkToCstmrDbtCdtNtfctn>
+</Document>`
+
+func TestParseCamt054Case(t *testing.T) {
+	credit, _ := GetDecimal("1250,5")
+	reversal, _ := GetDecimal("80,00")
+
+	expected := []Transaction{
+		{
+			Index: 1,
+			Statement: TransactionStatement{
+				ValueDate:            LongDate{Year: 23, Month: 6, Day: 2},
+				EntryDate:            &ShortDate{Month: 6, Day: 3},
+				TransactionType:      CREDIT,
+				Amount:               credit,
+				TransactionTypeCode:  "NTRF",
+				OwnerReference:       "INV-2023-17",
+				BankReference:        "2023060300001",
+				SupplementaryDetails: "SEPA credit transfer",
+			},
+			Information: TransactionInformation{
+				Info:         "Invoice 2023-17",
+				Title:        []string{"Invoice 2023-17"},
+				Counterparty: Counterparty{Name: "ACME GmbH", IBAN: "NL91ABNA0417164300", BIC: "ABNANL2A"},
+				Sepa:         map[string]string{"EREF": "INV-2023-17", "MREF": "MANDATE-1"},
+			},
+		},
+		{
+			Index: 2,
+			Statement: TransactionStatement{
+				ValueDate:           LongDate{Year: 23, Month: 6, Day: 3},
+				EntryDate:           &ShortDate{Month: 6, Day: 3},
+				TransactionType:     REVERSAL_CREDIT,
+				Amount:              reversal,
+				TransactionTypeCode: "NMSC",
+				OwnerReference:      "NONREF",
+			},
+			Information: TransactionInformation{Info: "Returned direct debit"},
+		},
+	}
+
+	actual, err := ParseCamt054(camt054Input)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestConvertToCamt054Case(t *testing.T) {
+	report, err := ParseMT942(mt942Input)
+	assert.Nil(t, err)
+
+	for _, version := range []CamtVersion{CAMT_054_001_02, CAMT_054_001_08} {
+		output, err := ConvertToCamt054([]MT942{*report}, version, WithCreationTime(camtCreationTime))
+		assert.Nil(t, err, version)
+
+		document := string(output)
+		assert.Contains(t, document, `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:`+string(version)+`">`, version)
+		assert.Contains(t, document, "<MsgId>INTERIM1</MsgId>\n      <CreDtTm>2023-06-03T12:15:00Z</CreDtTm>", version)
+		assert.Contains(t, document, "<ElctrncSeqNb>12</ElctrncSeqNb>\n      <CreDtTm>2023-06-03T12:15:00+02:00</CreDtTm>", version)
+		assert.Contains(t, document, "<TxsSummry>\n        <TtlCdtNtries>\n          <NbOfNtries>0</NbOfNtries>\n          <Sum>0</Sum>\n        </TtlCdtNtries>\n"+
+			"        <TtlDbtNtries>\n          <NbOfNtries>1</NbOfNtries>\n          <Sum>449.77</Sum>\n        </TtlDbtNtries>\n      </TxsSummry>", version)
+		assert.Equal(t, version.isVersion08(), strings.Contains(document, "<NtfctnPgntn>"), string(version))
+
+		actual, err := ParseCamt054(document)
+		assert.Nil(t, err, version)
+		assert.Len(t, actual, len(report.Transactions), version)
+		for i, transaction := range report.Transactions {
+			transaction.Statement.FundsCode = ""
+			assert.Equal(t, transaction.Statement, actual[i].Statement, version)
+			assert.Equal(t, transaction.Information.Info, actual[i].Information.Info, version)
+		}
+	}
+}
+
+func TestCamt054ErrorCase(t *testing.T) {
+	report, err := ParseMT942(mt942Input)
+	assert.Nil(t, err)
+
+	_, err = ConvertToCamt054([]MT942{*report}, CAMT_053_001_02)
+	assert.True(t, errors.Is(err, ErrUnsupportedMessage))
+
+	_, err = ConvertToCamt054(nil, CAMT_054_001_02)
+	assert.True(t, errors.Is(err, ErrMissingTag))
+
+	_, err = ParseCamt054(germanCamt053Output)
+	assert.True(t, errors.Is(err, ErrUnsupportedMessage))
+
+	_, err = ParseCamt054(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.054.001.02"><BkToCstmrDbtCdtNtfctn/></Document>`)
+	assert.True(t, errors.Is(err, ErrMissingTag))
+
+	actual, err := ParseCamt054(strings.Replace(camt054Input, "<CdtDbtInd>DBIT</CdtDbtInd>", "<CdtDbtInd>DB</CdtDbtInd>", 1))
+	assert.Nil(t, actual)
+	var parseError *ParseError
+	assert.True(t, errors.As(err, &parseError))
+	assert.Equal(t, ErrIncorrectType, parseError.Code)
+	assert.Equal(t, 2, parseError.MessageIndex)
+	assert.Equal(t, 2, parseError.Transaction)
+}
@@ -0,0 +1,92 @@
+package mt940_converter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	polishText    = "824 OPŁATA ZA PRZELEW; tyt.: zapłata za fakturę, Kraków Śląsk źródło"
+	ukrainianText = "Оплата за товар згідно рахунку, ТОВ Ромашка"
+)
+
+var encodedTexts = map[Encoding]string{
+	ENCODING_CP1250:     "824 OP\xA3ATA ZA PRZELEW; tyt.: zap\xB3ata za faktur\xEA, Krak\xF3w \x8Cl\xB9sk \x9Fr\xF3d\xB3o",
+	ENCODING_ISO_8859_2: "824 OP\xA3ATA ZA PRZELEW; tyt.: zap\xB3ata za faktur\xEA, Krak\xF3w \xA6l\xB1sk \xBCr\xF3d\xB3o",
+	ENCODING_CP852:      "824 OP\x9DATA ZA PRZELEW; tyt.: zap\x88ata za faktur\xA9, Krak\xA2w \x97l\xA5sk \xABr\xA2d\x88o",
+	ENCODING_MAZOVIA:    "824 OP\x9CATA ZA PRZELEW; tyt.: zap\x92ata za faktur\x91, Krak\xA2w \x98l\x86sk \xA6r\xA2d\x92o",
+	ENCODING_CP1251:     "\xCE\xEF\xEB\xE0\xF2\xE0 \xE7\xE0 \xF2\xEE\xE2\xE0\xF0 \xE7\xE3\xB3\xE4\xED\xEE \xF0\xE0\xF5\xF3\xED\xEA\xF3, \xD2\xCE\xC2 \xD0\xEE\xEC\xE0\xF8\xEA\xE0",
+	ENCODING_ISO_8859_5: "\xBE\xDF\xDB\xD0\xE2\xD0 \xD7\xD0 \xE2\xDE\xD2\xD0\xE0 \xD7\xD3\xF6\xD4\xDD\xDE \xE0\xD0\xE5\xE3\xDD\xDA\xE3, \xC2\xBE\xB2 \xC0\xDE\xDC\xD0\xE8\xDA\xD0",
+}
+
+func TestDecodeCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		encoding       Encoding
+		expectedResult string
+		hasError       bool
+	}
+
+	testTable := []testCase{
+		{name: "CP1250", input: encodedTexts[ENCODING_CP1250], encoding: ENCODING_CP1250, expectedResult: polishText, hasError: false},
+		{name: "ISO-8859-2", input: encodedTexts[ENCODING_ISO_8859_2], encoding: ENCODING_ISO_8859_2, expectedResult: polishText, hasError: false},
+		{name: "CP852", input: encodedTexts[ENCODING_CP852], encoding: ENCODING_CP852, expectedResult: polishText, hasError: false},
+		{name: "Mazovia", input: encodedTexts[ENCODING_MAZOVIA], encoding: ENCODING_MAZOVIA, expectedResult: polishText, hasError: false},
+		{name: "CP1251", input: encodedTexts[ENCODING_CP1251], encoding: ENCODING_CP1251, expectedResult: ukrainianText, hasError: false},
+		{name: "ISO-8859-5", input: encodedTexts[ENCODING_ISO_8859_5], encoding: ENCODING_ISO_8859_5, expectedResult: ukrainianText, hasError: false},
+		{name: "ISO-8859-1", input: "Gr\xFC\xDFe", encoding: ENCODING_ISO_8859_1, expectedResult: "Grüße", hasError: false},
+		{name: "UTF-8 with BOM", input: utf8BOM + polishText, encoding: ENCODING_UTF8, expectedResult: polishText, hasError: false},
+		{name: "Incorrect UTF-8", input: encodedTexts[ENCODING_CP1250], encoding: ENCODING_UTF8, expectedResult: "", hasError: true},
+		{name: "Unsupported encoding", input: polishText, encoding: "EBCDIC", expectedResult: "", hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := Decode(test.input, test.encoding)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestDetectEncodingCase(t *testing.T) {
+	for encoding, input := range encodedTexts {
+		assert.Equal(t, encoding, DetectEncoding(input), string(encoding))
+	}
+	assert.Equal(t, Encoding(ENCODING_UTF8), DetectEncoding(polishText))
+	assert.Equal(t, Encoding(ENCODING_UTF8), DetectEncoding(utf8BOM+ukrainianText))
+}
+
+func TestParseStatementEncodingCase(t *testing.T) {
+	input := strings.ReplaceAll(statementInput, "Ł", "\x9D")
+
+	actual, err := ParseStatement(input)
+	assert.Nil(t, err)
+	assert.Equal(t, Encoding(ENCODING_CP852), actual.Encoding)
+	assert.Equal(t, "824 OPŁATA ZA PRZELEW ELIXIR", actual.Transactions[0].Information.Info)
+
+	actual, err = ParseStatement(input, WithEncoding(ENCODING_UTF8))
+	assert.Nil(t, actual)
+	assert.True(t, errors.Is(err, ErrIncorrectCharacter))
+}
+
+func TestDecoderEncodingCase(t *testing.T) {
+	input := utf8BOM + strings.ReplaceAll(statementInput, "Ł", "\x9C") + secondStatementInput
+
+	decoder := NewDecoder(strings.NewReader(input), WithEncoding(ENCODING_MAZOVIA))
+	assert.True(t, decoder.Next())
+	assert.Equal(t, int64(len(utf8BOM)), decoder.Offset())
+	assert.Equal(t, Encoding(ENCODING_MAZOVIA), decoder.Statement().Encoding)
+	assert.Equal(t, "824 OPŁATA ZA PRZELEW ELIXIR", decoder.Statement().Transactions[0].Information.Info)
+	assert.True(t, decoder.Next())
+	assert.Equal(t, "SECOND", decoder.Statement().ReferenceNumber.Value)
+	assert.False(t, decoder.Next())
+	assert.Nil(t, decoder.Err())
+}
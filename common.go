@@ -1,44 +1,134 @@
 package mt940_converter
 
 import (
-	"errors"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
-	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 )
 
 func GetLongDate(s string) (*LongDate, error) {
+	return GetLongDateWithPivot(s, DEFAULT_CENTURY_PIVOT)
+}
+
+func GetLongDateWithPivot(s string, pivot int64) (*LongDate, error) {
 	if len(s) != 6 {
-		log.Error().Msg("Incorrect date length")
-		return nil, errors.New("incorrect date length")
+		return nil, newParseError(ErrIncorrectDate, s, "incorrect date length")
 	}
-	year, err := strconv.ParseInt(s[0:2], 10, 8)
-	month, err := strconv.ParseInt(s[2:4], 10, 8)
-	day, err := strconv.ParseInt(s[4:6], 10, 8)
-
-	if err != nil {
-		return nil, err
+	if !isNumeric(s) {
+		return nil, newParseError(ErrIncorrectDate, s, "incorrect date format")
 	}
-
-	return &LongDate{
+	year, _ := strconv.ParseInt(s[0:2], 10, 8)
+	month, _ := strconv.ParseInt(s[2:4], 10, 8)
+	day, _ := strconv.ParseInt(s[4:6], 10, 8)
+	result := LongDate{
 		Year:  year,
 		Month: month,
 		Day:   day,
-	}, nil
+	}
+	if !isCalendarDate(result.FullYear(pivot), month, day) {
+		return nil, newParseError(ErrIncorrectDate, s, "the date does not exist in the calendar")
+	}
+	return &result, nil
+}
+
+func (d LongDate) Compare(other LongDate) int {
+	switch {
+	case d.Year != other.Year:
+		return compareInt(d.Year, other.Year)
+	case d.Month != other.Month:
+		return compareInt(d.Month, other.Month)
+	default:
+		return compareInt(d.Day, other.Day)
+	}
+}
+
+func (d LongDate) FullYear(pivot int64) int64 {
+	if d.Year >= pivot {
+		return 1900 + d.Year
+	}
+	return 2000 + d.Year
+}
+
+func (d LongDate) Time() time.Time {
+	return d.TimeWithPivot(DEFAULT_CENTURY_PIVOT)
+}
+
+func (d LongDate) TimeWithPivot(pivot int64) time.Time {
+	return time.Date(int(d.FullYear(pivot)), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, time.UTC)
+}
+
+const maxResolveDistance = 183 * 24 * time.Hour
+
+func (d ShortDate) Resolve(reference LongDate) (LongDate, error) {
+	base := reference.FullYear(DEFAULT_CENTURY_PIVOT)
+	referenceTime := reference.Time()
+
+	var year int64
+	var distance time.Duration = -1
+	for _, candidate := range []int64{base - 1, base, base + 1} {
+		if !isCalendarDate(candidate, d.Month, d.Day) {
+			continue
+		}
+		candidateDistance := time.Date(int(candidate), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, time.UTC).Sub(referenceTime)
+		if candidateDistance < 0 {
+			candidateDistance = -candidateDistance
+		}
+		if candidateDistance > maxResolveDistance {
+			continue
+		}
+		if distance < 0 || candidateDistance < distance {
+			year, distance = candidate, candidateDistance
+		}
+	}
+	if distance < 0 {
+		return LongDate{}, newParseError(ErrIncorrectDate, fmt.Sprintf("%02d%02d", d.Month, d.Day), "the date does not exist within half a year of %v", FormatLongDate(reference))
+	}
+	return LongDate{Year: year % 100, Month: d.Month, Day: d.Day}, nil
+}
+
+func (t TransactionStatement) BookingDate() LongDate {
+	if t.EntryDate == nil {
+		return t.ValueDate
+	}
+	if date, err := t.EntryDate.Resolve(t.ValueDate); err == nil {
+		return date
+	}
+	return t.ValueDate
+}
+
+func isCalendarDate(year int64, month int64, day int64) bool {
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+	return day <= int64(time.Date(int(year), time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day())
+}
+
+func compareInt(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
 }
 
 func GetShortDate(s string) (*ShortDate, error) {
 	if len(s) != 4 {
-		return nil, errors.New("incorrect date length")
+		return nil, newParseError(ErrIncorrectDate, s, "incorrect date length")
 	}
-	month, err := strconv.ParseInt(s[0:2], 10, 8)
-	day, err := strconv.ParseInt(s[2:4], 10, 8)
-
-	if err != nil {
-		return nil, err
+	if !isNumeric(s) {
+		return nil, newParseError(ErrIncorrectDate, s, "incorrect date format")
+	}
+	month, _ := strconv.ParseInt(s[0:2], 10, 8)
+	day, _ := strconv.ParseInt(s[2:4], 10, 8)
+	if !isCalendarDate(leapYear, month, day) {
+		return nil, newParseError(ErrIncorrectDate, s, "the date does not exist in the calendar")
 	}
 
 	return &ShortDate{
@@ -48,17 +138,25 @@ func GetShortDate(s string) (*ShortDate, error) {
 }
 
 func GetDecimal(s string) (MyDecimal, error) {
-	numberWithoutComma := strings.ReplaceAll(s, ",", "")
+	number := s
+	if index := strings.LastIndex(s, ","); index >= 0 {
+		number = strings.ReplaceAll(s[:index], ",", "") + "." + s[index+1:]
+	}
 
-	decimalNumber, err := decimal.NewFromString(numberWithoutComma)
+	decimalNumber, err := decimal.NewFromString(number)
 	if err != nil {
-		return MyDecimal{}, err
+		result := newParseError(ErrIncorrectAmount, s, "incorrect amount format")
+		result.Err = err
+		return MyDecimal{}, result
 	}
 
-	return MyDecimal(decimalNumber.Div(decimal.NewFromInt(100))), nil
+	return MyDecimal(decimalNumber), nil
 }
 
 func GetLastNChars(input string, number int) string {
+	if number < 0 || number > len(input) {
+		return ""
+	}
 	s, done := validateString(input[len(input)-number:])
 	if done {
 		return s
@@ -67,6 +165,9 @@ func GetLastNChars(input string, number int) string {
 }
 
 func GetFirstNChars(input string, number int) string {
+	if number < 0 || number > len(input) {
+		return ""
+	}
 	s, done := validateString(input[:number])
 	if done {
 		return s
@@ -75,6 +176,15 @@ func GetFirstNChars(input string, number int) string {
 
 }
 
+func isNumeric(input string) bool {
+	for _, char := range input {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func validateString(input string) (string, bool) {
 	alphabetic := true
 	for _, char := range input {
@@ -92,3 +202,49 @@ func validateString(input string) (string, bool) {
 func GetTransactionType(result string) TransactionType {
 	return TransactionType(result)
 }
+
+func (t TransactionType) IsReversal() bool {
+	return t == REVERSAL_DEBIT || t == REVERSAL_CREDIT
+}
+
+func (t TransactionType) IsExpected() bool {
+	return t == EXPECTED_DEBIT || t == EXPECTED_CREDIT
+}
+
+func (t TransactionType) Sign() int {
+	switch t {
+	case CREDIT, REVERSAL_DEBIT, EXPECTED_CREDIT:
+		return 1
+	case DEBIT, REVERSAL_CREDIT, EXPECTED_DEBIT:
+		return -1
+	}
+	return 0
+}
+
+func (s TransactionStatement) SignedAmount() MyDecimal {
+	return signedAmount(s.Amount, s.TransactionType)
+}
+
+func (b Balance) SignedAmount() MyDecimal {
+	return signedAmount(b.Amount, b.TransactionType)
+}
+
+func signedAmount(amount MyDecimal, transactionType TransactionType) MyDecimal {
+	return MyDecimal(decimal.Decimal(amount).Mul(decimal.NewFromInt(int64(transactionType.Sign()))))
+}
+
+func tagValue(input string, tag string) string {
+	index := strings.Index(input, tag)
+	if index < 0 {
+		return ""
+	}
+	result := input[index+len(tag):]
+	if index := strings.IndexAny(result, "\r\n"); index >= 0 {
+		return result[:index]
+	}
+	return result
+}
+
+func normalizeLineEndings(input string) string {
+	return strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(input)
+}
@@ -2,7 +2,9 @@ package mt940_converter
 
 import (
 	"testing"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,11 +22,14 @@ func TestGetLongDateCase(t *testing.T) {
 			Month: 2,
 			Day:   22,
 		}, hasError: false},
-		{name: "Long date is empty", input: "032211", expectedResult: &LongDate{
-			Year:  3,
-			Month: 22,
-			Day:   11,
+		{name: "Long date with incorrect month", input: "032211", expectedResult: nil, hasError: true},
+		{name: "Long date with incorrect day", input: "230631", expectedResult: nil, hasError: true},
+		{name: "Long date on leap day", input: "240229", expectedResult: &LongDate{
+			Year:  24,
+			Month: 2,
+			Day:   29,
 		}, hasError: false},
+		{name: "Long date on leap day of common year", input: "230229", expectedResult: nil, hasError: true},
 		{name: "Long date is too long", input: "02010522222", expectedResult: nil, hasError: true},
 		{name: "Long date is too short", input: "1111", expectedResult: nil, hasError: true},
 	}
@@ -41,6 +46,34 @@ func TestGetLongDateCase(t *testing.T) {
 	}
 
 }
+
+func TestGetLongDateWithPivotCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		pivot          int64
+		expectedResult *LongDate
+		hasError       bool
+	}
+
+	testTable := []testCase{
+		{name: "Leap day of 2000", input: "000229", pivot: DEFAULT_CENTURY_PIVOT, expectedResult: &LongDate{Year: 0, Month: 2, Day: 29}},
+		{name: "Leap day of 1900", input: "000229", pivot: 0, expectedResult: nil, hasError: true},
+		{name: "Leap day of 1996", input: "960229", pivot: DEFAULT_CENTURY_PIVOT, expectedResult: &LongDate{Year: 96, Month: 2, Day: 29}},
+	}
+
+	for _, test := range testTable {
+		actual, err := GetLongDateWithPivot(test.input, test.pivot)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.ErrorIs(t, err, ErrIncorrectDate, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
 func TestGetShortDateCase(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -54,9 +87,11 @@ func TestGetShortDateCase(t *testing.T) {
 			Month: 2,
 			Day:   22,
 		}, hasError: false},
-		{name: "Short date is empty", input: "2211", expectedResult: &ShortDate{
-			Month: 22,
-			Day:   11,
+		{name: "Short date with incorrect month", input: "2211", expectedResult: nil, hasError: true},
+		{name: "Short date with incorrect day", input: "0230", expectedResult: nil, hasError: true},
+		{name: "Short date on leap day", input: "0229", expectedResult: &ShortDate{
+			Month: 2,
+			Day:   29,
 		}, hasError: false},
 		{name: "Short date is too long", input: "02010522222", expectedResult: nil, hasError: true},
 		{name: "Short date is too short", input: "111", expectedResult: nil, hasError: true},
@@ -74,3 +109,122 @@ func TestGetShortDateCase(t *testing.T) {
 	}
 
 }
+func TestLongDateTimeCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		date           LongDate
+		pivot          int64
+		expectedResult time.Time
+	}
+
+	testTable := []testCase{
+		{name: "Year before pivot", date: LongDate{Year: 7, Month: 10, Day: 9}, pivot: DEFAULT_CENTURY_PIVOT, expectedResult: time.Date(2007, 10, 9, 0, 0, 0, 0, time.UTC)},
+		{name: "Year after pivot", date: LongDate{Year: 99, Month: 12, Day: 31}, pivot: DEFAULT_CENTURY_PIVOT, expectedResult: time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "Year equal to pivot", date: LongDate{Year: 50, Month: 1, Day: 1}, pivot: 50, expectedResult: time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Year with custom pivot", date: LongDate{Year: 85, Month: 6, Day: 1}, pivot: 90, expectedResult: time.Date(2085, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range testTable {
+		assert.Equal(t, test.expectedResult, test.date.TimeWithPivot(test.pivot), test.name)
+	}
+	assert.Equal(t, time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), LongDate{Year: 23, Month: 6, Day: 2}.Time())
+}
+
+func TestShortDateResolveCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		date           ShortDate
+		reference      LongDate
+		expectedResult LongDate
+		hasError       bool
+	}
+
+	testTable := []testCase{
+		{name: "Same year", date: ShortDate{Month: 6, Day: 3}, reference: LongDate{Year: 23, Month: 6, Day: 2}, expectedResult: LongDate{Year: 23, Month: 6, Day: 3}},
+		{name: "December value date and January entry date", date: ShortDate{Month: 1, Day: 2}, reference: LongDate{Year: 23, Month: 12, Day: 31}, expectedResult: LongDate{Year: 24, Month: 1, Day: 2}},
+		{name: "January value date and December entry date", date: ShortDate{Month: 12, Day: 30}, reference: LongDate{Year: 24, Month: 1, Day: 1}, expectedResult: LongDate{Year: 23, Month: 12, Day: 30}},
+		{name: "Roll-over into next century", date: ShortDate{Month: 1, Day: 1}, reference: LongDate{Year: 99, Month: 12, Day: 31}, expectedResult: LongDate{Year: 0, Month: 1, Day: 1}},
+		{name: "Roll-over into previous century", date: ShortDate{Month: 12, Day: 31}, reference: LongDate{Year: 0, Month: 1, Day: 2}, expectedResult: LongDate{Year: 99, Month: 12, Day: 31}},
+		{name: "Leap day in the reference year", date: ShortDate{Month: 2, Day: 29}, reference: LongDate{Year: 24, Month: 3, Day: 1}, expectedResult: LongDate{Year: 24, Month: 2, Day: 29}},
+		{name: "Leap day in the following year", date: ShortDate{Month: 2, Day: 29}, reference: LongDate{Year: 23, Month: 12, Day: 31}, expectedResult: LongDate{Year: 24, Month: 2, Day: 29}},
+		{name: "Leap day without a leap year nearby", date: ShortDate{Month: 2, Day: 29}, reference: LongDate{Year: 23, Month: 3, Day: 1}, hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := test.date.Resolve(test.reference)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.ErrorIs(t, err, ErrIncorrectDate, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestTransactionStatementBookingDateCase(t *testing.T) {
+	statement, err := GetStatement("2312310102CN449,77NTRFSP300\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, LongDate{Year: 24, Month: 1, Day: 2}, statement.BookingDate())
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), statement.BookingDate().Time())
+
+	statement, err = GetStatement("230602C100,NTRFINV-2023-001\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, statement.ValueDate, statement.BookingDate())
+
+	_, err = GetStatement("2303010229CN449,77NTRFSP300\r\n")
+	assert.ErrorIs(t, err, ErrIncorrectDate)
+}
+
+func TestGetDecimalCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult string
+		hasError       bool
+	}
+
+	testTable := []testCase{
+		{name: "Decimal with two fraction digits", input: "73447,91", expectedResult: "73447.91", hasError: false},
+		{name: "Decimal without fraction digits", input: "100,", expectedResult: "100", hasError: false},
+		{name: "Decimal with one fraction digit", input: "2,5", expectedResult: "2.5", hasError: false},
+		{name: "Decimal with thousands separator", input: "1,234,56", expectedResult: "1234.56", hasError: false},
+		{name: "Decimal is incorrect", input: "12a,00", expectedResult: "0", hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := GetDecimal(test.input)
+		assert.Equal(t, test.expectedResult, decimal.Decimal(actual).String(), test.name)
+
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestTransactionTypeCase(t *testing.T) {
+	type testCase struct {
+		name                 string
+		transactionType      TransactionType
+		expectedReversal     bool
+		expectedSignedAmount string
+	}
+
+	amount, _ := GetDecimal("12,50")
+	testTable := []testCase{
+		{name: "Debit", transactionType: DEBIT, expectedReversal: false, expectedSignedAmount: "-12.5"},
+		{name: "Credit", transactionType: CREDIT, expectedReversal: false, expectedSignedAmount: "12.5"},
+		{name: "Reversal of debit", transactionType: REVERSAL_DEBIT, expectedReversal: true, expectedSignedAmount: "12.5"},
+		{name: "Reversal of credit", transactionType: REVERSAL_CREDIT, expectedReversal: true, expectedSignedAmount: "-12.5"},
+		{name: "Expected debit", transactionType: EXPECTED_DEBIT, expectedReversal: false, expectedSignedAmount: "-12.5"},
+		{name: "Unknown mark", transactionType: "X", expectedReversal: false, expectedSignedAmount: "0"},
+	}
+
+	for _, test := range testTable {
+		stmt := TransactionStatement{TransactionType: test.transactionType, Amount: amount}
+		assert.Equal(t, test.expectedReversal, test.transactionType.IsReversal(), test.name)
+		assert.Equal(t, test.expectedSignedAmount, decimal.Decimal(stmt.SignedAmount()).String(), test.name)
+	}
+}
@@ -1,12 +1,9 @@
 package mt940_converter
 
 import (
-	"errors"
-	"fmt"
 	"regexp"
 	"strings"
 
-	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 )
 
@@ -18,11 +15,17 @@ const (
 	statementNumber        = ":28C:"
 	openingBalance         = ":60F:"
 	closingBalance         = ":62F:"
+	intermediateOpening    = ":60M:"
+	intermediateClosing    = ":62M:"
 	availableBalance       = ":64:"
+	forwardBalance         = ":65:"
+	balanceStatementNumber = ":28:"
 	transaction            = ":61:"
 	transactionDescription = ":86:"
 )
 
+var statementPattern = regexp.MustCompile(`^([0-9]{6})([0-9]{4})?(RC|RD|EC|ED|C|D)([A-Z])?([0-9][0-9,]{0,14})([NFS][A-Z0-9]{3})(.*)$`)
+
 type ReferenceNumber struct {
 	Value string
 }
@@ -47,17 +50,30 @@ type ShortDate struct {
 	Month int64
 	Day   int64
 }
+
+const (
+	DEFAULT_CENTURY_PIVOT int64 = 69
+	leapYear              int64 = 2000
+)
+
 type TransactionType string
 type BalanceType string
 
 const (
-	DEBIT  TransactionType = "D"
-	CREDIT                 = "C"
+	DEBIT           TransactionType = "D"
+	CREDIT                          = "C"
+	REVERSAL_DEBIT                  = "RD"
+	REVERSAL_CREDIT                 = "RC"
+	EXPECTED_DEBIT                  = "ED"
+	EXPECTED_CREDIT                 = "EC"
 )
 const (
-	OPENING   BalanceType = "O"
-	CLOSING               = "C"
-	AVAILABLE             = "A"
+	OPENING              BalanceType = "O"
+	CLOSING                          = "C"
+	AVAILABLE                        = "A"
+	FORWARD_AVAILABLE                = "F"
+	INTERMEDIATE_OPENING             = "IO"
+	INTERMEDIATE_CLOSING             = "IC"
 )
 
 type MyDecimal decimal.Decimal
@@ -70,16 +86,26 @@ type Balance struct {
 	BalanceType     BalanceType
 }
 type TransactionStatement struct {
-	LongDate               LongDate
-	ShortDate              ShortDate
-	TransactionType        TransactionType
-	ThirdCurrencyCharacter string
-	Amount                 MyDecimal
-	Description            string
-	DescriptionPrefix      string
+	ValueDate            LongDate
+	EntryDate            *ShortDate
+	TransactionType      TransactionType
+	FundsCode            string
+	Amount               MyDecimal
+	TransactionTypeCode  string
+	OwnerReference       string
+	BankReference        string
+	SupplementaryDetails string
 }
 type TransactionInformation struct {
-	Info string
+	Info         string
+	Code         string
+	Description  string
+	Primanota    string
+	Title        []string
+	Counterparty Counterparty
+	Sepa         map[string]string
+	Subfields    []Subfield
+	Dutch        *DutchInformation
 }
 type Transaction struct {
 	Index       int
@@ -90,12 +116,11 @@ type Transaction struct {
 func GetReferenceNumber(input string) (*ReferenceNumber, error) {
 
 	if !strings.Contains(input, referenceNumber) {
-		return nil, fmt.Errorf("no reference number tag found. Expected tag: %s", referenceNumber)
+		return nil, newTagNotFoundError(input, "reference number", referenceNumber)
 	}
-	index := strings.Index(input, crlf)
-	result := input[len(referenceNumber):index]
+	result := tagValue(input, referenceNumber)
 	if len(result) > 16 {
-		return nil, fmt.Errorf("the reference number character size is bigger than 16. Size: %v", len(input))
+		return nil, newFieldError(ErrIncorrectLength, referenceNumber, result, 16, "the reference number character size is bigger than 16. Size: %v", len(result))
 	}
 	return &ReferenceNumber{Value: result}, nil
 }
@@ -103,37 +128,36 @@ func GetReferenceNumber(input string) (*ReferenceNumber, error) {
 func GetRelatedReference(input string) (*RelatedReference, error) {
 
 	if !strings.Contains(input, relatedReference) {
-		return nil, fmt.Errorf("no related reference tag found. Expected tag: %s", relatedReference)
+		return nil, newTagNotFoundError(input, "related reference", relatedReference)
 	}
-	index := strings.Index(input, crlf)
-	result := input[len(relatedReference):index]
+	result := tagValue(input, relatedReference)
 	if len(result) > 16 {
-		return nil, fmt.Errorf("the related reference character size is bigger than 16. Size: %v", len(input))
+		return nil, newFieldError(ErrIncorrectLength, relatedReference, result, 16, "the related reference character size is bigger than 16. Size: %v", len(result))
 	}
 	return &RelatedReference{Value: result}, nil
 }
 
 func GetAccountIdentification(input string) (*AccountIdentification, error) {
 	if !strings.Contains(input, accountIdentification) {
-		return nil, fmt.Errorf("no account identification tag found. Expected tag: %s", accountIdentification)
+		return nil, newTagNotFoundError(input, "account identification", accountIdentification)
 	}
 
-	index := strings.Index(input, crlf)
-	iban := input[len(accountIdentification):index]
+	iban := tagValue(input, accountIdentification)
 	if len(iban) == 0 {
-		return nil, fmt.Errorf("the reference number is empty. Size: %v", len(input))
+		return nil, newFieldError(ErrIncorrectLength, accountIdentification, iban, 0, "the account identification is empty")
 	}
 	if len(iban) > 35 {
-		return nil, fmt.Errorf("the reference number character size is bigger than 35. Size: %v", len(input))
+		return nil, newFieldError(ErrIncorrectLength, accountIdentification, iban, 35, "the account identification character size is bigger than 35. Size: %v", len(iban))
 	}
 	currency := GetLastNChars(iban, 3)
 	country := GetFirstNChars(iban, 2)
 	if len(country) == 0 {
-		return nil, fmt.Errorf("the reference number does not contain country ISO code")
+		return nil, newFieldError(ErrIncorrectFormat, accountIdentification, iban, 0, "the account identification does not contain country ISO code")
 	}
-	if len(currency) != 0 {
+	if len(currency) != 0 && len(iban) >= len(country)+len(currency) {
 		iban = iban[len(country) : len(iban)-3]
 	} else {
+		currency = ""
 		iban = iban[len(country):]
 	}
 	return &AccountIdentification{
@@ -146,16 +170,29 @@ func GetAccountIdentification(input string) (*AccountIdentification, error) {
 func GetStatementNumber(input string) (*StatementNumber, error) {
 
 	if !strings.Contains(input, statementNumber) {
-		return nil, fmt.Errorf("no statement number tag found. Expected tag: %s", statementNumber)
+		return nil, newTagNotFoundError(input, "statement number", statementNumber)
+	}
+	result := tagValue(input, statementNumber)
+	number, sequence, _ := strings.Cut(result, "/")
+	if len(number) > 5 {
+		return nil, newFieldError(ErrIncorrectLength, statementNumber, result, 5, "the statement number character size is bigger than 5. Size: %v", len(number))
 	}
-	index := strings.Index(input, crlf)
-	result := input[len(statementNumber):index]
-	if len(result) > 5 {
-		return nil, fmt.Errorf("the statement number character size is bigger than 5. Size: %v", len(input))
+	if len(sequence) > 5 {
+		return nil, newFieldError(ErrIncorrectLength, statementNumber, result, len(number)+6, "the sequence number character size is bigger than 5. Size: %v", len(sequence))
 	}
 	return &StatementNumber{Value: result}, nil
 }
 
+func (s StatementNumber) Number() string {
+	number, _, _ := strings.Cut(s.Value, "/")
+	return number
+}
+
+func (s StatementNumber) Sequence() string {
+	_, sequence, _ := strings.Cut(s.Value, "/")
+	return sequence
+}
+
 func GetBalance(input string, balanceType BalanceType) (*Balance, error) {
 	var tag string
 	if balanceType == OPENING {
@@ -167,26 +204,33 @@ func GetBalance(input string, balanceType BalanceType) (*Balance, error) {
 	if balanceType == AVAILABLE {
 		tag = availableBalance
 	}
+	if balanceType == FORWARD_AVAILABLE {
+		tag = forwardBalance
+	}
+	if balanceType == INTERMEDIATE_OPENING {
+		tag = intermediateOpening
+	}
+	if balanceType == INTERMEDIATE_CLOSING {
+		tag = intermediateClosing
+	}
 	if tag == "" {
-		return nil, fmt.Errorf("incorrect tag: %v", tag)
-
+		return nil, newParseError(ErrIncorrectType, input, "incorrect balance type: %v", balanceType)
 	}
 
 	if !strings.Contains(input, tag) {
-		return nil, fmt.Errorf("no proper tag found. Expected tag: %s", tag)
+		return nil, newTagNotFoundError(input, "balance", tag)
 	}
-	index := strings.Index(input, crlf)
-	result := input[len(tag):index]
+	result := tagValue(input, tag)
 	if len(result) > 25 || len(result) < 10 {
-		return nil, fmt.Errorf("the balance character size is incorrect. Size: %v", len(input))
+		return nil, newFieldError(ErrIncorrectLength, tag, result, 0, "the balance character size is incorrect. Size: %v", len(result))
 	}
 	amount, err := GetDecimal(result[10:])
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse amount. Error: %v", err)
+		return nil, wrapFieldError(err, tag, result, 10, "cannot parse amount")
 	}
 	date, err := GetLongDate(result[1:7])
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse date. Error: %v", err)
+		return nil, wrapFieldError(err, tag, result, 1, "cannot parse date")
 	}
 
 	return &Balance{
@@ -198,64 +242,99 @@ func GetBalance(input string, balanceType BalanceType) (*Balance, error) {
 	}, nil
 }
 
-func GetTransactions(input string) (*[]Transaction, error) {
+func GetTransactions(input string, opts ...Option) (*[]Transaction, error) {
+	o := newOptions(opts)
 	transactionStrings := strings.Split(input, transaction)[1:]
 	var transactions []Transaction
-	var err error
+	var errs ParseErrors
 	for i, transactionString := range transactionStrings {
 		statement, err := GetStatement(transactionString)
-		info := GetTransactionInfo(transactionString)
 		if err != nil {
-			break
+			parseError := asParseError(err)
+			parseError.Transaction = i + 1
+			if !o.isLenient() {
+				return &[]Transaction{}, parseError
+			}
+			errs = append(errs, parseError)
+			continue
 		}
 
 		transactions = append(transactions, Transaction{
 			Index:       i + 1,
 			Statement:   *statement,
-			Information: info,
+			Information: GetTransactionInfo(transactionString),
 		})
 	}
-	if err != nil {
-		return &[]Transaction{}, err
+	if len(errs) > 0 {
+		return &transactions, errs
 	}
 	return &transactions, nil
 }
 
 func GetTransactionInfo(transactionString string) TransactionInformation {
+	if !strings.Contains(transactionString, transactionDescription) {
+		return TransactionInformation{}
+	}
 	var info = transactionString[strings.LastIndex(transactionString, transactionDescription)+len(transactionDescription):]
-	log.Printf(info)
+	return parseInformation(info)
+}
+
+func parseInformation(info string) TransactionInformation {
+	if result, err := ParseDutchInformation(info); err == nil {
+		return newDutchTransactionInformation(info, result)
+	}
+	if result, err := ParseGermanInformation(info); err == nil && result.Sepa != nil {
+		return *result
+	}
+	if result, err := ParsePolishInformation(info); err == nil {
+		return *result
+	}
+	if result, err := ParseUkrainianInformation(info); err == nil {
+		return *result
+	}
 	return TransactionInformation{Info: info}
 }
 
 func GetStatement(transactionString string) (*TransactionStatement, error) {
-	var stmt = transactionString[:strings.Index(transactionString, transactionDescription)]
-	valueLongDate, err := GetLongDate(stmt[:6])
-	valueShortDate, err := GetShortDate(stmt[6:10])
-	var transactionType = GetTransactionType(stmt[10:11])
-	regex := regexp.MustCompile("^([A-Za-z])?(\\d{1,12},\\d{2}|\\d{1,3},\\d{3},\\d{2}|\\d{1,15})([A-Za-z])(.*?)$")
-	matches := regex.FindStringSubmatch(regexp.MustCompile(`\r?\n`).ReplaceAllString(stmt[11:], " "))
+	var stmt = transactionString
+	if index := strings.Index(transactionString, transactionDescription); index >= 0 {
+		stmt = transactionString[:index]
+	}
+	line, details, _ := strings.Cut(normalizeLineEndings(stmt), "\n")
+	matches := statementPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return nil, newFieldError(ErrIncorrectFormat, transaction, line, 0, "the input statement string is incorrect")
+	}
+
+	valueDate, err := GetLongDate(matches[1])
 	if err != nil {
-		return nil, err
+		return nil, wrapFieldError(err, transaction, line, 0, "cannot parse value date")
 	}
-	if matches != nil {
-		thirdCurrencyCharacter := matches[1]
-		amount, err := GetDecimal(matches[2])
-		descriptionPrefix := matches[3]
-		descr := matches[4]
+	var entryDate *ShortDate
+	if matches[2] != "" {
+		entryDate, err = GetShortDate(matches[2])
 		if err != nil {
-			return nil, err
+			return nil, wrapFieldError(err, transaction, line, 6, "cannot parse entry date")
 		}
+		if _, err := entryDate.Resolve(*valueDate); err != nil {
+			return nil, wrapFieldError(err, transaction, line, 6, "cannot resolve entry date")
+		}
+	}
+	amount, err := GetDecimal(matches[5])
+	if err != nil {
+		return nil, wrapFieldError(err, transaction, line, len(matches[1])+len(matches[2])+len(matches[3])+len(matches[4]), "cannot parse amount")
+	}
+	ownerReference, bankReference, _ := strings.Cut(matches[7], "//")
 
-		return &TransactionStatement{
-			LongDate:               *valueLongDate,
-			ShortDate:              *valueShortDate,
-			TransactionType:        transactionType,
-			ThirdCurrencyCharacter: thirdCurrencyCharacter,
-			Amount:                 amount,
-			DescriptionPrefix:      descriptionPrefix,
-			Description:            descr,
-		}, nil
-	}
-	return nil, errors.New("the input statement string is incorrect")
-
+	return &TransactionStatement{
+		ValueDate:            *valueDate,
+		EntryDate:            entryDate,
+		TransactionType:      GetTransactionType(matches[3]),
+		FundsCode:            matches[4],
+		Amount:               amount,
+		TransactionTypeCode:  matches[6],
+		OwnerReference:       ownerReference,
+		BankReference:        bankReference,
+		SupplementaryDetails: strings.TrimSpace(details),
+	}, nil
 }
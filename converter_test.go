@@ -1,6 +1,8 @@
 package mt940_converter
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -276,6 +278,57 @@ func TestAvailableBalanceCase(t *testing.T) {
 	}
 }
 
+func TestForwardAvailableBalanceCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult *Balance
+		hasError       bool
+	}
+
+	decim1, _ := GetDecimal("73447,91")
+	decim2, _ := GetDecimal("734488877,91")
+	testTable := []testCase{
+		{name: "Forward available balance is correct", input: ":65:C120216UAH73447,91\r\n", expectedResult: &Balance{
+			TransactionType: CREDIT,
+			Date: LongDate{
+				Year:  12,
+				Month: 2,
+				Day:   16,
+			},
+			Currency:    "UAH",
+			Amount:      decim1,
+			BalanceType: FORWARD_AVAILABLE,
+		}, hasError: false},
+		{name: "Forward available balance is correct", input: ":65:D110122PLN734488877,91\r\n", expectedResult: &Balance{
+			TransactionType: DEBIT,
+			Date: LongDate{
+				Year:  11,
+				Month: 1,
+				Day:   22,
+			},
+			Currency:    "PLN",
+			Amount:      decim2,
+			BalanceType: FORWARD_AVAILABLE,
+		}, hasError: false},
+		{name: "Forward available balance is empty", input: ":65:\r\n", expectedResult: nil, hasError: true},
+		{name: "Forward available balance is too short", input: ":65:C\r\n", expectedResult: nil, hasError: true},
+		{name: "Forward available balance is too long", input: ":65:C120216UAH73447,9wwww\r\n", expectedResult: nil, hasError: true},
+		{name: "Forward available balance tag not found", input: ":64:01234\r\n", expectedResult: nil, hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := GetBalance(test.input, FORWARD_AVAILABLE)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
 func TestGetTransactionsCase(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -294,20 +347,22 @@ func TestGetTransactionsCase(t *testing.T) {
 				{
 					Index: 1,
 					Statement: TransactionStatement{
-						LongDate: LongDate{
+						ValueDate: LongDate{
 							Year:  7,
 							Month: 10,
 							Day:   9,
 						},
-						ShortDate: ShortDate{
+						EntryDate: &ShortDate{
 							Month: 10,
 							Day:   9,
 						},
-						TransactionType:        DEBIT,
-						ThirdCurrencyCharacter: "N",
-						Amount:                 decim1,
-						DescriptionPrefix:      "N",
-						Description:            "CHGNONREF//BR07282102000059 824-OPŁ. ZA PRZEL. ELIXIR MT ",
+						TransactionType:      DEBIT,
+						FundsCode:            "N",
+						Amount:               decim1,
+						TransactionTypeCode:  "NCHG",
+						OwnerReference:       "NONREF",
+						BankReference:        "BR07282102000059",
+						SupplementaryDetails: "824-OPŁ. ZA PRZEL. ELIXIR MT",
 					},
 					Information: TransactionInformation{Info: "824 OPŁATA ZA PRZELEW ELIXIR; TNR: 145271016138274.040001\n"},
 				}},
@@ -321,62 +376,89 @@ func TestGetTransactionsCase(t *testing.T) {
 				{
 					Index: 1,
 					Statement: TransactionStatement{
-						LongDate: LongDate{
+						ValueDate: LongDate{
 							Year:  7,
 							Month: 10,
 							Day:   9,
 						},
-						ShortDate: ShortDate{
+						EntryDate: &ShortDate{
 							Month: 10,
 							Day:   9,
 						},
-						TransactionType:        DEBIT,
-						ThirdCurrencyCharacter: "N",
-						Amount:                 decim1,
-						DescriptionPrefix:      "N",
-						Description:            "CHGNONREF//BR07282102000059 824-OPŁ. ZA PRZEL. ELIXIR MT ",
+						TransactionType:      DEBIT,
+						FundsCode:            "N",
+						Amount:               decim1,
+						TransactionTypeCode:  "NCHG",
+						OwnerReference:       "NONREF",
+						BankReference:        "BR07282102000059",
+						SupplementaryDetails: "824-OPŁ. ZA PRZEL. ELIXIR MT",
 					},
 					Information: TransactionInformation{Info: "824 OPŁATA ZA PRZELEW ELIXIR; TNR: 145271016138274.040001\n"},
 				},
 				{
 					Index: 2,
 					Statement: TransactionStatement{
-						LongDate: LongDate{
+						ValueDate: LongDate{
 							Year:  5,
 							Month: 1,
 							Day:   12,
 						},
-						ShortDate: ShortDate{
+						EntryDate: &ShortDate{
 							Month: 1,
 							Day:   12,
 						},
-						TransactionType:        DEBIT,
-						ThirdCurrencyCharacter: "N",
-						Amount:                 decim2,
-						DescriptionPrefix:      "N",
-						Description:            "TRFSP300//BR05012139000001 944-PRZEL.KRAJ.WYCH.MT.ELX ",
+						TransactionType:      DEBIT,
+						FundsCode:            "N",
+						Amount:               decim2,
+						TransactionTypeCode:  "NTRF",
+						OwnerReference:       "SP300",
+						BankReference:        "BR05012139000001",
+						SupplementaryDetails: "944-PRZEL.KRAJ.WYCH.MT.ELX",
 					},
 					Information: TransactionInformation{Info: "944 CompanyNet Przelew krajowy; na rach.: 35109010560000000006093440; dla: PHU Test ul.Dolna\n1 00-950 Warszawa; tyt.: fv 100/2007; TNR: 145271016138277.020002"},
 				},
 				{
 					Index: 3,
 					Statement: TransactionStatement{
-						LongDate: LongDate{
+						ValueDate: LongDate{
 							Year:  23,
 							Month: 6,
 							Day:   4,
 						},
-						ShortDate: ShortDate{
+						EntryDate: &ShortDate{
 							Month: 6,
 							Day:   4,
 						},
-						TransactionType:        DEBIT,
-						ThirdCurrencyCharacter: "",
-						Amount:                 decim3,
-						DescriptionPrefix:      "S",
-						Description:            "07397301056237 ",
+						TransactionType:     DEBIT,
+						Amount:              decim3,
+						TransactionTypeCode: "S073",
+						OwnerReference:      "97301056237",
+					},
+					Information: TransactionInformation{
+						Info:        "073~00VE02\n~20PàatnoòÜ kart• 02.06.2023 \n~21Nr karty 4246xx4970~22\n~23~24\n~25\n~3010500031~311915031/19730\n~32BOLT.EU/R/2306021457      ~33Tallinn \n~34073",
+						Code:        "073",
+						Description: "VE02",
+						Title:       []string{"PàatnoòÜ kart• 02.06.2023", "Nr karty 4246xx4970"},
+						Counterparty: Counterparty{
+							Name:     "BOLT.EU/R/2306021457 Tallinn",
+							Account:  "1915031/19730",
+							BankCode: "10500031",
+						},
+						Subfields: []Subfield{
+							{Key: "00", Value: "VE02"},
+							{Key: "20", Value: "PàatnoòÜ kart• 02.06.2023 "},
+							{Key: "21", Value: "Nr karty 4246xx4970"},
+							{Key: "22", Value: ""},
+							{Key: "23", Value: ""},
+							{Key: "24", Value: ""},
+							{Key: "25", Value: ""},
+							{Key: "30", Value: "10500031"},
+							{Key: "31", Value: "1915031/19730"},
+							{Key: "32", Value: "BOLT.EU/R/2306021457      "},
+							{Key: "33", Value: "Tallinn "},
+							{Key: "34", Value: "073"},
+						},
 					},
-					Information: TransactionInformation{Info: "073~00VE02\n~20PàatnoòÜ kart• 02.06.2023 \n~21Nr karty 4246xx4970~22\n~23~24\n~25\n~3010500031~311915031/19730\n~32BOLT.EU/R/2306021457      ~33Tallinn \n~34073"},
 				}},
 			hasError: false,
 		},
@@ -393,3 +475,109 @@ func TestGetTransactionsCase(t *testing.T) {
 		}
 	}
 }
+
+func TestGetStatementCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult *TransactionStatement
+		hasError       bool
+	}
+
+	decim1, _ := GetDecimal("100,")
+	decim2, _ := GetDecimal("1234,56")
+	testTable := []testCase{
+		{name: "Statement without entry date and bank reference", input: "230602C100,NTRFINV-2023-001\r\n", expectedResult: &TransactionStatement{
+			ValueDate:           LongDate{Year: 23, Month: 6, Day: 2},
+			TransactionType:     CREDIT,
+			Amount:              decim1,
+			TransactionTypeCode: "NTRF",
+			OwnerReference:      "INV-2023-001",
+		}, hasError: false},
+		{name: "Statement with reversal, funds code and supplementary details", input: "2306020603RDR1234,56FMSCNONREF//8327000090031789\r\nCard reversal\r\n:86:info", expectedResult: &TransactionStatement{
+			ValueDate:            LongDate{Year: 23, Month: 6, Day: 2},
+			EntryDate:            &ShortDate{Month: 6, Day: 3},
+			TransactionType:      REVERSAL_DEBIT,
+			FundsCode:            "R",
+			Amount:               decim2,
+			TransactionTypeCode:  "FMSC",
+			OwnerReference:       "NONREF",
+			BankReference:        "8327000090031789",
+			SupplementaryDetails: "Card reversal",
+		}, hasError: false},
+		{name: "Statement with reversal of credit", input: "2306020603RC1234,56NMSCNONREF\r\n", expectedResult: &TransactionStatement{
+			ValueDate:           LongDate{Year: 23, Month: 6, Day: 2},
+			EntryDate:           &ShortDate{Month: 6, Day: 3},
+			TransactionType:     REVERSAL_CREDIT,
+			Amount:              decim2,
+			TransactionTypeCode: "NMSC",
+			OwnerReference:      "NONREF",
+		}, hasError: false},
+		{name: "Statement without transaction type code", input: "230602C100,\r\n", expectedResult: nil, hasError: true},
+		{name: "Statement without debit/credit mark", input: "230602100,NTRFNONREF\r\n", expectedResult: nil, hasError: true},
+		{name: "Statement is empty", input: "", expectedResult: nil, hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := GetStatement(test.input)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestLineEndingsCase(t *testing.T) {
+	type testCase struct {
+		name  string
+		parse func(input string) (interface{}, error)
+		input string
+	}
+
+	testTable := []testCase{
+		{name: "Reference number", input: ":20:referenceNumber1", parse: func(input string) (interface{}, error) { return GetReferenceNumber(input) }},
+		{name: "Related reference", input: ":21:relatedReference", parse: func(input string) (interface{}, error) { return GetRelatedReference(input) }},
+		{name: "Account identification", input: ":25:NL17RABO6064103256EUR", parse: func(input string) (interface{}, error) { return GetAccountIdentification(input) }},
+		{name: "Statement number", input: ":28C:44444", parse: func(input string) (interface{}, error) { return GetStatementNumber(input) }},
+		{name: "Balance", input: ":60F:C120216UAH73447,91", parse: func(input string) (interface{}, error) { return GetBalance(input, OPENING) }},
+		{name: "Statement", input: "0710091009DN2,50NCHGNONREF//BR07282102000059\r\n824-OPŁ. ZA PRZEL. ELIXIR MT", parse: func(input string) (interface{}, error) { return GetStatement(input) }},
+	}
+
+	for _, test := range testTable {
+		expected, err := test.parse(test.input + "\r\n")
+		assert.Nil(t, err, test.name)
+
+		for _, lineEnding := range []string{"\n", "\r"} {
+			input := strings.ReplaceAll(test.input, "\r\n", lineEnding) + lineEnding
+			actual, err := test.parse(input)
+			assert.Nil(t, err, test.name)
+			assert.Equal(t, expected, actual, test.name)
+		}
+
+		actual, err := test.parse(test.input)
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, expected, actual, test.name)
+	}
+}
+
+func TestGetTransactionsModeCase(t *testing.T) {
+	input := ":61:2306020602DN2,50NCHGNONREF\n:86:first\n" +
+		":61:2306030603XN449,77NTRFSP300\n:86:second\n" +
+		":61:2306040604CN1,89NTRFSP301\n:86:third\n"
+
+	actual, err := GetTransactions(input)
+	assert.Equal(t, &[]Transaction{}, actual)
+	assert.True(t, errors.Is(err, ErrIncorrectFormat))
+
+	actual, err = GetTransactions(input, WithMode(LENIENT_MODE))
+	var errs ParseErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, 2, errs[0].Transaction)
+	assert.Len(t, *actual, 2)
+	assert.Equal(t, 1, (*actual)[0].Index)
+	assert.Equal(t, 3, (*actual)[1].Index)
+}
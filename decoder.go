@@ -0,0 +1,223 @@
+package mt940_converter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const textBlockEnd = "-}"
+
+type Decoder struct {
+	reader    *bufio.Reader
+	opts      []Option
+	offset    int64
+	line      int
+	pending   *decoderLine
+	index     int
+	start     int64
+	startLine int
+	statement *Statement
+	report    *MT942
+	err       error
+}
+
+type decoderLine struct {
+	text   string
+	offset int64
+	line   int
+}
+
+func NewDecoder(r io.Reader, opts ...Option) *Decoder {
+	return &Decoder{reader: bufio.NewReader(r), opts: opts}
+}
+
+func (d *Decoder) Next() bool {
+	if d.err != nil {
+		return false
+	}
+	message, start, err := d.readMessage()
+	if err != nil {
+		d.statement, d.report = nil, nil
+		if err != io.EOF {
+			d.err = err
+		}
+		return false
+	}
+
+	d.index++
+	d.start = start
+	d.statement, d.report = nil, nil
+	var diagnostics []*ParseError
+	if detectMessageType(message, newOptions(d.opts)) == MESSAGE_942 {
+		d.report, err = ParseMT942(message, d.opts...)
+		if err == nil {
+			diagnostics = append(d.report.Errors, d.report.Warnings...)
+		}
+	} else {
+		d.statement, err = ParseStatement(message, d.opts...)
+		if err == nil {
+			diagnostics = append(d.statement.Errors, d.statement.Warnings...)
+		}
+	}
+	if err != nil {
+		var parseError *ParseError
+		if errors.As(err, &parseError) {
+			d.locate(parseError)
+		}
+		d.err = fmt.Errorf("cannot parse message %v at offset %v. Error: %w", d.index, d.start, err)
+		return false
+	}
+	for _, parseError := range diagnostics {
+		d.locate(parseError)
+	}
+	return true
+}
+
+func (d *Decoder) locate(err *ParseError) {
+	err.MessageIndex = d.index
+	withLineOffset(err, d.startLine-1)
+}
+
+func (d *Decoder) Statement() *Statement {
+	return d.statement
+}
+
+func (d *Decoder) MT942() *MT942 {
+	return d.report
+}
+
+func (d *Decoder) MessageType() MessageType {
+	switch {
+	case d.report != nil:
+		return MESSAGE_942
+	case d.statement != nil:
+		return d.statement.MessageType
+	default:
+		return ""
+	}
+}
+
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+func (d *Decoder) Index() int {
+	return d.index
+}
+
+func (d *Decoder) Offset() int64 {
+	return d.start
+}
+
+func (d *Decoder) readMessage() (string, int64, error) {
+	var message strings.Builder
+	var start int64
+	started := false
+	wrapped := false
+	hasFields := false
+	hasReference := false
+
+	for {
+		line, err := d.readLine()
+		if err != nil {
+			if err == io.EOF && hasFields {
+				return message.String(), start, nil
+			}
+			return "", 0, err
+		}
+
+		text := strings.TrimRight(line.text, "\r\n")
+		switch {
+		case strings.HasPrefix(text, "{") && hasFields:
+			d.pending = &line
+			return message.String(), start, nil
+		case strings.HasPrefix(text, textBlockEnd):
+			if !hasFields {
+				continue
+			}
+			message.WriteString(line.text)
+			if !strings.Contains(text, "{"+trailerBlock+":") {
+				d.appendTrailer(&message)
+			}
+			return message.String(), start, nil
+		case text == messageEnd:
+			if hasFields {
+				return message.String(), start, nil
+			}
+			continue
+		case strings.TrimSpace(text) == "" && !started:
+			continue
+		case strings.HasPrefix(text, referenceNumber):
+			if hasReference {
+				d.pending = &line
+				return message.String(), start, nil
+			}
+			hasReference = true
+		}
+
+		if !started {
+			started = true
+			start = line.offset
+			d.startLine = line.line
+			wrapped = strings.HasPrefix(text, "{")
+		}
+		if strings.HasPrefix(text, "{") && !wrapped {
+			continue
+		}
+		hasFields = hasFields || tagPattern.MatchString(line.text)
+		message.WriteString(line.text)
+	}
+}
+
+func (d *Decoder) appendTrailer(message *strings.Builder) {
+	line, err := d.readLine()
+	if err != nil {
+		return
+	}
+	if strings.HasPrefix(line.text, "{"+trailerBlock+":") {
+		message.WriteString(line.text)
+		return
+	}
+	d.pending = &line
+}
+
+func (d *Decoder) readLine() (decoderLine, error) {
+	if d.pending != nil {
+		line := *d.pending
+		d.pending = nil
+		return line, nil
+	}
+	if d.offset == 0 {
+		if prefix, err := d.reader.Peek(len(utf8BOM)); err == nil && string(prefix) == utf8BOM {
+			_, _ = d.reader.Discard(len(utf8BOM))
+			d.offset += int64(len(utf8BOM))
+		}
+	}
+	var text []byte
+	for {
+		b, err := d.reader.ReadByte()
+		if err != nil {
+			if len(text) == 0 || err != io.EOF {
+				return decoderLine{}, err
+			}
+			break
+		}
+		text = append(text, b)
+		if b == '\n' {
+			break
+		}
+		if b == '\r' {
+			if next, err := d.reader.Peek(1); err == nil && next[0] == '\n' {
+				continue
+			}
+			break
+		}
+	}
+	d.line++
+	line := decoderLine{text: string(text), offset: d.offset, line: d.line}
+	d.offset += int64(len(text))
+	return line, nil
+}
@@ -0,0 +1,141 @@
+package mt940_converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const secondStatementInput = ":20:SECOND\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28C:00002\r\n" +
+	":60F:C230603EUR1447,27\r\n" +
+	":62F:C230604EUR1447,27\r\n"
+
+const wrappedStatementInput = "{1:F01BANKBEBBAXXX0000000000}{2:O9401200230603BANKDEFFAXXX00000000002306031200N}{4:\r\n" +
+	secondStatementInput + "-}"
+
+func TestDecoderCase(t *testing.T) {
+	type testCase struct {
+		name               string
+		input              string
+		expectedReferences []string
+		expectedOffsets    []int64
+	}
+
+	testTable := []testCase{
+		{
+			name:               "Messages separated by dash lines",
+			input:              statementInput + secondStatementInput + "-\r\n",
+			expectedReferences: []string{"STARTUMS", "SECOND"},
+			expectedOffsets:    []int64{0, int64(len(statementInput))},
+		},
+		{
+			name:               "Messages without separators",
+			input:              strings.TrimSuffix(statementInput, "-\r\n") + secondStatementInput,
+			expectedReferences: []string{"STARTUMS", "SECOND"},
+			expectedOffsets:    []int64{0, int64(len(statementInput) - 3)},
+		},
+		{
+			name:               "Messages wrapped in SWIFT blocks",
+			input:              wrappedStatementInput + "\r\n" + wrappedStatementInput,
+			expectedReferences: []string{"SECOND", "SECOND"},
+			expectedOffsets:    []int64{0, int64(len(wrappedStatementInput) + 2)},
+		},
+		{
+			name:               "Messages wrapped in SWIFT blocks with trailer on separate line",
+			input:              wrappedStatementInput + "\r\n{5:{CHK:123456789ABC}}\r\n" + wrappedStatementInput,
+			expectedReferences: []string{"SECOND", "SECOND"},
+			expectedOffsets:    []int64{0, int64(len(wrappedStatementInput) + 26)},
+		},
+		{
+			name:               "Messages with bare carriage returns",
+			input:              strings.ReplaceAll(statementInput+secondStatementInput, "\r\n", "\r"),
+			expectedReferences: []string{"STARTUMS", "SECOND"},
+			expectedOffsets:    []int64{0, int64(len(statementInput) - strings.Count(statementInput, "\r\n"))},
+		},
+		{
+			name:               "Empty input",
+			input:              "\r\n",
+			expectedReferences: nil,
+			expectedOffsets:    nil,
+		},
+	}
+
+	for _, test := range testTable {
+		decoder := NewDecoder(strings.NewReader(test.input))
+		var references []string
+		var offsets []int64
+		for decoder.Next() {
+			references = append(references, decoder.Statement().ReferenceNumber.Value)
+			offsets = append(offsets, decoder.Offset())
+			assert.Equal(t, len(references), decoder.Index(), test.name)
+		}
+		assert.Nil(t, decoder.Err(), test.name)
+		assert.Equal(t, test.expectedReferences, references, test.name)
+		assert.Equal(t, test.expectedOffsets, offsets, test.name)
+	}
+}
+
+func TestDecoderErrorCase(t *testing.T) {
+	decoder := NewDecoder(strings.NewReader(statementInput + ":20:BROKEN\r\n:25:NL17RABO6064103256EUR\r\n-\r\n"))
+
+	assert.True(t, decoder.Next())
+	assert.False(t, decoder.Next())
+	assert.Nil(t, decoder.Statement())
+	assert.NotNil(t, decoder.Err())
+	assert.Equal(t, 2, decoder.Index())
+	assert.Equal(t, int64(len(statementInput)), decoder.Offset())
+}
+
+func TestDecoderMessageTypeCase(t *testing.T) {
+	type testCase struct {
+		name               string
+		input              string
+		opts               []Option
+		expectedTypes      []MessageType
+		expectedReferences []string
+	}
+
+	testTable := []testCase{
+		{
+			name:               "MT941 and MT942 inferred from their fields",
+			input:              mt941Input + mt942Input + statementInput,
+			expectedTypes:      []MessageType{MESSAGE_941, MESSAGE_942, MESSAGE_940},
+			expectedReferences: []string{"MT941REF", "INTERIM1", "STARTUMS"},
+		},
+		{
+			name:               "MT950 selected explicitly",
+			input:              statementInput + secondStatementInput,
+			opts:               []Option{WithMessageType(MESSAGE_950)},
+			expectedTypes:      []MessageType{MESSAGE_950, MESSAGE_950},
+			expectedReferences: []string{"STARTUMS", "SECOND"},
+		},
+		{
+			name:               "Message type taken from the SWIFT envelope",
+			input:              strings.Replace(wrappedStatementInput, "O940", "O950", 1),
+			expectedTypes:      []MessageType{MESSAGE_950},
+			expectedReferences: []string{"SECOND"},
+		},
+	}
+
+	for _, test := range testTable {
+		decoder := NewDecoder(strings.NewReader(test.input), test.opts...)
+		var types []MessageType
+		var references []string
+		for decoder.Next() {
+			types = append(types, decoder.MessageType())
+			if decoder.MessageType() == MESSAGE_942 {
+				assert.Nil(t, decoder.Statement(), test.name)
+				references = append(references, decoder.MT942().ReferenceNumber.Value)
+			} else {
+				assert.Nil(t, decoder.MT942(), test.name)
+				references = append(references, decoder.Statement().ReferenceNumber.Value)
+			}
+		}
+		assert.Nil(t, decoder.Err(), test.name)
+		assert.Equal(t, test.expectedTypes, types, test.name)
+		assert.Equal(t, test.expectedReferences, references, test.name)
+	}
+}
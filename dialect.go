@@ -0,0 +1,254 @@
+package mt940_converter
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type DetectionHints struct {
+	BIC         string
+	Account     string
+	Information []string
+}
+
+type Dialect interface {
+	Name() string
+	Detect(hints DetectionHints) bool
+	ParseAccount(input string) (*AccountIdentification, error)
+	ParseInformation(input string) TransactionInformation
+}
+
+const (
+	SWIFT_DIALECT     = "swift"
+	POLISH_DIALECT    = "pl"
+	GERMAN_DIALECT    = "de"
+	DUTCH_DIALECT     = "nl"
+	UKRAINIAN_DIALECT = "ua"
+)
+
+var registry = struct {
+	sync.RWMutex
+	dialects []Dialect
+}{}
+
+var (
+	polishAccountPattern = regexp.MustCompile(`^/?(PL)?([0-9]{26})$`)
+	germanAccountPattern = regexp.MustCompile(`^([0-9]{8})/([0-9]{1,10})([A-Z]{3})?$`)
+)
+
+func init() {
+	RegisterDialect(swiftDialect{})
+	RegisterDialect(polishDialect{})
+	RegisterDialect(germanDialect{})
+	RegisterDialect(dutchDialect{})
+	RegisterDialect(ukrainianDialect{})
+}
+
+func RegisterDialect(dialect Dialect) {
+	registry.Lock()
+	defer registry.Unlock()
+	for i, registered := range registry.dialects {
+		if registered.Name() == dialect.Name() {
+			registry.dialects[i] = dialect
+			return
+		}
+	}
+	registry.dialects = append(registry.dialects, dialect)
+}
+
+func LookupDialect(name string) (Dialect, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	for _, dialect := range registry.dialects {
+		if dialect.Name() == name {
+			return dialect, true
+		}
+	}
+	return nil, false
+}
+
+func DetectDialect(hints DetectionHints) Dialect {
+	registry.RLock()
+	defer registry.RUnlock()
+	for i := len(registry.dialects) - 1; i >= 0; i-- {
+		if registry.dialects[i].Detect(hints) {
+			return registry.dialects[i]
+		}
+	}
+	return swiftDialect{}
+}
+
+func getDetectionHints(fields []field, envelope *FinEnvelope) DetectionHints {
+	var hints DetectionHints
+	if envelope != nil {
+		hints.BIC = envelope.SenderBIC()
+	}
+	for _, f := range fields {
+		switch f.Tag {
+		case accountIdentification:
+			hints.Account = f.Value
+		case transactionDescription:
+			hints.Information = append(hints.Information, f.Value)
+		}
+	}
+	return hints
+}
+
+func bicCountry(bic string) string {
+	if len(bic) < 6 {
+		return ""
+	}
+	return bic[4:6]
+}
+
+type swiftDialect struct{}
+
+func (swiftDialect) Name() string {
+	return SWIFT_DIALECT
+}
+
+func (swiftDialect) Detect(DetectionHints) bool {
+	return false
+}
+
+func (swiftDialect) ParseAccount(input string) (*AccountIdentification, error) {
+	return GetAccountIdentification(input)
+}
+
+func (swiftDialect) ParseInformation(input string) TransactionInformation {
+	return parseInformation(input)
+}
+
+type polishDialect struct{}
+
+func (polishDialect) Name() string {
+	return POLISH_DIALECT
+}
+
+func (polishDialect) Detect(hints DetectionHints) bool {
+	if bicCountry(hints.BIC) == "PL" || polishAccountPattern.MatchString(hints.Account) {
+		return true
+	}
+	for _, info := range hints.Information {
+		if matches := subfieldsPattern.FindStringSubmatch(info); matches != nil && matches[2] == "~" {
+			return true
+		}
+	}
+	return false
+}
+
+func (polishDialect) ParseAccount(input string) (*AccountIdentification, error) {
+	if matches := polishAccountPattern.FindStringSubmatch(tagValue(input, accountIdentification)); matches != nil {
+		return &AccountIdentification{CountryIso: "PL", Iban: matches[2]}, nil
+	}
+	return GetAccountIdentification(input)
+}
+
+func (polishDialect) ParseInformation(input string) TransactionInformation {
+	if result, err := ParsePolishInformation(input); err == nil {
+		return *result
+	}
+	return TransactionInformation{Info: input}
+}
+
+type germanDialect struct{}
+
+func (germanDialect) Name() string {
+	return GERMAN_DIALECT
+}
+
+func (germanDialect) Detect(hints DetectionHints) bool {
+	if bicCountry(hints.BIC) == "DE" || germanAccountPattern.MatchString(hints.Account) || strings.HasPrefix(hints.Account, "DE") {
+		return true
+	}
+	for _, info := range hints.Information {
+		if matches := subfieldsPattern.FindStringSubmatch(info); matches != nil && sepaKeywordPattern.MatchString(info) {
+			return true
+		}
+	}
+	return false
+}
+
+func (germanDialect) ParseAccount(input string) (*AccountIdentification, error) {
+	if matches := germanAccountPattern.FindStringSubmatch(tagValue(input, accountIdentification)); matches != nil {
+		return &AccountIdentification{CountryIso: "DE", Iban: matches[1] + "/" + matches[2], Currency: matches[3]}, nil
+	}
+	return GetAccountIdentification(input)
+}
+
+func (germanDialect) ParseInformation(input string) TransactionInformation {
+	if result, err := ParseGermanInformation(input); err == nil {
+		return *result
+	}
+	return TransactionInformation{Info: input}
+}
+
+type dutchDialect struct{}
+
+func (dutchDialect) Name() string {
+	return DUTCH_DIALECT
+}
+
+func (dutchDialect) Detect(hints DetectionHints) bool {
+	if bicCountry(hints.BIC) == "NL" || strings.HasPrefix(hints.Account, "NL") {
+		return true
+	}
+	for _, info := range hints.Information {
+		if _, ok := dutchKeyAt(info, 0, ""); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (dutchDialect) ParseAccount(input string) (*AccountIdentification, error) {
+	return GetAccountIdentification(input)
+}
+
+func (dutchDialect) ParseInformation(input string) TransactionInformation {
+	if result, err := ParseDutchInformation(input); err == nil {
+		return newDutchTransactionInformation(input, result)
+	}
+	return parseInformation(input)
+}
+
+type ukrainianDialect struct{}
+
+func (ukrainianDialect) Name() string {
+	return UKRAINIAN_DIALECT
+}
+
+func (ukrainianDialect) Detect(hints DetectionHints) bool {
+	if bicCountry(hints.BIC) == "UA" || strings.HasPrefix(hints.Account, "UA") {
+		return true
+	}
+	for _, info := range hints.Information {
+		if hasUkrainianDetails(info) && ukrainianTaxIDPattern.MatchString(DecodeCP1251(info)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ukrainianDialect) ParseAccount(input string) (*AccountIdentification, error) {
+	account := tagValue(input, accountIdentification)
+	matches := ukrainianAccountPattern.FindStringSubmatch(account)
+	if matches == nil {
+		if strings.HasPrefix(account, "UA") {
+			return nil, newFieldError(ErrIncorrectFormat, accountIdentification, account, 0, "the Ukrainian account must be a 29 character IBAN")
+		}
+		return GetAccountIdentification(input)
+	}
+	if err := ValidateUkrainianIBAN(matches[1]); err != nil {
+		return nil, wrapFieldError(err, accountIdentification, account, 0, "incorrect Ukrainian IBAN")
+	}
+	return &AccountIdentification{CountryIso: "UA", Iban: matches[1][2:], Currency: matches[2]}, nil
+}
+
+func (ukrainianDialect) ParseInformation(input string) TransactionInformation {
+	if result, err := ParseUkrainianInformation(input); err == nil {
+		return *result
+	}
+	return TransactionInformation{Info: DecodeCP1251(input)}
+}
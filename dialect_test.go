@@ -0,0 +1,107 @@
+package mt940_converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDialect struct {
+	swiftDialect
+}
+
+func (testDialect) Name() string {
+	return "test"
+}
+
+func (testDialect) Detect(hints DetectionHints) bool {
+	return hints.BIC == "TESTXXXXXXX"
+}
+
+func (testDialect) ParseInformation(input string) TransactionInformation {
+	return TransactionInformation{Info: input, Code: "TEST"}
+}
+
+func TestDetectDialectCase(t *testing.T) {
+	type testCase struct {
+		name            string
+		hints           DetectionHints
+		expectedDialect string
+	}
+
+	testTable := []testCase{
+		{name: "Polish BIC", hints: DetectionHints{BIC: "BREXPLPWXXX"}, expectedDialect: POLISH_DIALECT},
+		{name: "Polish NRB account", hints: DetectionHints{Account: "/PL61109010140000071219812874"}, expectedDialect: POLISH_DIALECT},
+		{name: "Polish tilde subfields", hints: DetectionHints{Account: "X", Information: []string{"073~00VE02~20Title"}}, expectedDialect: POLISH_DIALECT},
+		{name: "German BIC", hints: DetectionHints{BIC: "COBADEFFXXX"}, expectedDialect: GERMAN_DIALECT},
+		{name: "German BLZ account", hints: DetectionHints{Account: "37040044/0532013000"}, expectedDialect: GERMAN_DIALECT},
+		{name: "German SEPA subfields", hints: DetectionHints{Information: []string{"166?00SEPA?20EREF+1"}}, expectedDialect: GERMAN_DIALECT},
+		{name: "Dutch account", hints: DetectionHints{Account: "NL17RABO6064103256EUR"}, expectedDialect: DUTCH_DIALECT},
+		{name: "Dutch information", hints: DetectionHints{Information: []string{"/TRTP/SEPA OVERBOEKING/NAME/X"}}, expectedDialect: DUTCH_DIALECT},
+		{name: "Ukrainian BIC", hints: DetectionHints{BIC: "PBANUA2XXXX"}, expectedDialect: UKRAINIAN_DIALECT},
+		{name: "Ukrainian IBAN", hints: DetectionHints{Account: "UA213223130000026007233566001UAH"}, expectedDialect: UKRAINIAN_DIALECT},
+		{name: "Ukrainian tax ID", hints: DetectionHints{Information: []string{"Оплата; ЄДРПОУ 12345678"}}, expectedDialect: UKRAINIAN_DIALECT},
+		{name: "Unknown bank", hints: DetectionHints{BIC: "BANKBEBBXXX", Account: "BE68539007547034"}, expectedDialect: SWIFT_DIALECT},
+	}
+
+	for _, test := range testTable {
+		assert.Equal(t, test.expectedDialect, DetectDialect(test.hints).Name(), test.name)
+	}
+}
+
+func TestDialectRegistryCase(t *testing.T) {
+	registry.RLock()
+	dialects := append([]Dialect(nil), registry.dialects...)
+	registry.RUnlock()
+	t.Cleanup(func() {
+		registry.Lock()
+		defer registry.Unlock()
+		registry.dialects = dialects
+	})
+
+	RegisterDialect(testDialect{})
+
+	dialect, ok := LookupDialect("test")
+	assert.True(t, ok)
+	assert.Equal(t, "test", dialect.Name())
+	assert.Equal(t, "test", DetectDialect(DetectionHints{BIC: "TESTXXXXXXX"}).Name())
+
+	_, ok = LookupDialect("unknown")
+	assert.False(t, ok)
+}
+
+func TestParseStatementWithDialectCase(t *testing.T) {
+	input := ":20:REF\r\n" +
+		":25:37040044/0532013000EUR\r\n" +
+		":28C:1\r\n" +
+		":60F:C230601EUR1000,00\r\n" +
+		":61:2306020602CN100,NTRFNONREF\r\n" +
+		":86:166?00SEPA-GUTSCHRIFT?20EREF+E2E-1?21SVWZ+Miete?32Max Mustermann\r\n" +
+		":62F:C230602EUR1100,00\r\n" +
+		"-\r\n"
+
+	actual, err := ParseStatement(input)
+	assert.Nil(t, err)
+	assert.Equal(t, GERMAN_DIALECT, actual.Dialect)
+	assert.Equal(t, AccountIdentification{CountryIso: "DE", Iban: "37040044/0532013000", Currency: "EUR"}, actual.AccountIdentification)
+	assert.Equal(t, map[string]string{"EREF": "E2E-1", "SVWZ": "Miete"}, actual.Transactions[0].Information.Sepa)
+
+	polish, _ := LookupDialect(POLISH_DIALECT)
+	actual, err = ParseStatement(input, WithDialect(polish))
+	assert.NotNil(t, err)
+	assert.Nil(t, actual)
+
+	actual, err = ParseStatement(statementInput, WithDialect(testDialect{}))
+	assert.Nil(t, err)
+	assert.Equal(t, "test", actual.Dialect)
+	assert.Equal(t, "TEST", actual.Transactions[0].Information.Code)
+}
+
+func TestDutchDialectFallbackCase(t *testing.T) {
+	dutch, _ := LookupDialect(DUTCH_DIALECT)
+
+	actual := dutch.ParseInformation("020?00PRZELEW KRAJOWY?20Faktura VAT?27ACME SP. Z O.O.")
+	assert.Equal(t, "020", actual.Code)
+	assert.Equal(t, "ACME SP. Z O.O.", actual.Counterparty.Name)
+	assert.Equal(t, parseInformation("free text"), dutch.ParseInformation("free text"))
+}
@@ -0,0 +1,147 @@
+package mt940_converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+type DutchInformation struct {
+	TransactionType   string
+	IBAN              string
+	BIC               string
+	Name              string
+	Remittance        string
+	EndToEndReference string
+	MandateReference  string
+	CreditorID        string
+	Fields            []Subfield
+	Unknown           []Subfield
+}
+
+const remittanceKey = "REMI"
+
+var dutchKeys = []string{
+	"TRTP", "IBAN", "BIC", "NAME", remittanceKey, "EREF", "MARF", "CSID", "PREF", "RTRN",
+	"ORDP", "BENM", "ID", "ADDR", "ULTC", "ULTD", "PURP", "NRTX", "CDTRREF", "CDTRREFTP",
+}
+
+var dutchKeyPattern = regexp.MustCompile(`^[A-Z]{2,9}$`)
+
+func ParseDutchInformation(input string) (*DutchInformation, error) {
+	text := strings.NewReplacer("\r", "", "\n", "").Replace(input)
+	if _, ok := dutchKeyAt(text, 0, ""); !ok {
+		return nil, newParseError(ErrIncorrectFormat, input, "the information does not start with a slash-delimited key")
+	}
+
+	var info DutchInformation
+	for position := 0; position < len(text); {
+		key, _ := dutchKeyAt(text, position, "")
+		start := position + len(key) + 2
+		end := len(text)
+		for next := start; next < len(text); next++ {
+			if text[next] != '/' {
+				continue
+			}
+			if _, ok := dutchKeyAt(text, next, key); ok {
+				end = next
+				break
+			}
+		}
+		value := ""
+		if start < end {
+			value = strings.TrimSuffix(text[start:end], "/")
+		}
+		info.add(Subfield{Key: key, Value: value})
+		position = end
+	}
+	return &info, nil
+}
+
+func (d *DutchInformation) add(subfield Subfield) {
+	d.Fields = append(d.Fields, subfield)
+	switch subfield.Key {
+	case "TRTP":
+		d.TransactionType = subfield.Value
+	case "IBAN":
+		d.IBAN = subfield.Value
+	case "BIC":
+		d.BIC = subfield.Value
+	case "NAME":
+		d.Name = subfield.Value
+	case remittanceKey:
+		d.Remittance = getRemittance(subfield.Value)
+	case "EREF":
+		d.EndToEndReference = subfield.Value
+	case "MARF":
+		d.MandateReference = subfield.Value
+	case "CSID":
+		d.CreditorID = subfield.Value
+	default:
+		if !containsKey(dutchKeys, subfield.Key) {
+			d.Unknown = append(d.Unknown, subfield)
+		}
+	}
+}
+
+func dutchKeyAt(text string, position int, currentKey string) (string, bool) {
+	if position >= len(text) || text[position] != '/' {
+		return "", false
+	}
+	end := strings.IndexByte(text[position+1:], '/')
+	if end < 0 {
+		return "", false
+	}
+	key := text[position+1 : position+1+end]
+	if containsKey(dutchKeys, key) {
+		return key, true
+	}
+	return key, currentKey != remittanceKey && dutchKeyPattern.MatchString(key)
+}
+
+func getRemittance(value string) string {
+	if rest, ok := cutPrefix(value, "USTD//"); ok {
+		return rest
+	}
+	if rest, ok := cutPrefix(value, "STRD/CUR/"); ok {
+		return rest
+	}
+	return value
+}
+
+func cutPrefix(s string, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+func newDutchTransactionInformation(input string, dutch *DutchInformation) TransactionInformation {
+	info := TransactionInformation{
+		Info:        input,
+		Description: dutch.TransactionType,
+		Counterparty: Counterparty{
+			Name: dutch.Name,
+			IBAN: dutch.IBAN,
+			BIC:  dutch.BIC,
+		},
+		Subfields: dutch.Fields,
+		Dutch:     dutch,
+	}
+	if dutch.Remittance != "" {
+		info.Title = []string{dutch.Remittance}
+	}
+	sepa := map[string]string{
+		"EREF": dutch.EndToEndReference,
+		"MREF": dutch.MandateReference,
+		"CRED": dutch.CreditorID,
+	}
+	for key, value := range sepa {
+		if value == "" {
+			delete(sepa, key)
+		}
+	}
+	if len(sepa) > 0 {
+		info.Sepa = sepa
+	}
+	return info
+}
@@ -0,0 +1,87 @@
+package mt940_converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDutchInformationCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult *DutchInformation
+		hasError       bool
+	}
+
+	testTable := []testCase{
+		{
+			name: "Rabobank SEPA transfer",
+			input: "/TRTP/SEPA OVERBOEKING/IBAN/NL44RABO0123456789/BIC/RABONL2U/NAME/J. JANSEN/REMI/USTD//FACTUUR 2023/\n" +
+				"0042/EREF/NOTPROVIDED/XYZ/extra value",
+			expectedResult: &DutchInformation{
+				TransactionType:   "SEPA OVERBOEKING",
+				IBAN:              "NL44RABO0123456789",
+				BIC:               "RABONL2U",
+				Name:              "J. JANSEN",
+				Remittance:        "FACTUUR 2023/0042",
+				EndToEndReference: "NOTPROVIDED",
+				Fields: []Subfield{
+					{Key: "TRTP", Value: "SEPA OVERBOEKING"},
+					{Key: "IBAN", Value: "NL44RABO0123456789"},
+					{Key: "BIC", Value: "RABONL2U"},
+					{Key: "NAME", Value: "J. JANSEN"},
+					{Key: "REMI", Value: "USTD//FACTUUR 2023/0042"},
+					{Key: "EREF", Value: "NOTPROVIDED"},
+					{Key: "XYZ", Value: "extra value"},
+				},
+				Unknown: []Subfield{{Key: "XYZ", Value: "extra value"}},
+			},
+			hasError: false,
+		},
+		{
+			name:  "SEPA direct debit with mandate",
+			input: "/TRTP/SEPA INCASSO ALGEMEEN DOORLOPEND/CSID/NL98ZZZ999999999999/NAME/ENERGIE BV/MARF/M-1/EREF/E-1/REMI/STRD/CUR/1234567890/",
+			expectedResult: &DutchInformation{
+				TransactionType:   "SEPA INCASSO ALGEMEEN DOORLOPEND",
+				Name:              "ENERGIE BV",
+				Remittance:        "1234567890",
+				EndToEndReference: "E-1",
+				MandateReference:  "M-1",
+				CreditorID:        "NL98ZZZ999999999999",
+				Fields: []Subfield{
+					{Key: "TRTP", Value: "SEPA INCASSO ALGEMEEN DOORLOPEND"},
+					{Key: "CSID", Value: "NL98ZZZ999999999999"},
+					{Key: "NAME", Value: "ENERGIE BV"},
+					{Key: "MARF", Value: "M-1"},
+					{Key: "EREF", Value: "E-1"},
+					{Key: "REMI", Value: "STRD/CUR/1234567890"},
+				},
+			},
+			hasError: false,
+		},
+		{name: "Unstructured information", input: "824 OPŁATA ZA PRZELEW ELIXIR", expectedResult: nil, hasError: true},
+		{name: "Lowercase key", input: "/abc/value", expectedResult: nil, hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := ParseDutchInformation(test.input)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestGetTransactionInfoDutchCase(t *testing.T) {
+	actual := GetTransactionInfo("2306020602CN100,NTRFNONREF\n:86:/TRTP/SEPA OVERBOEKING/IBAN/NL44RABO0123456789/BIC/RABONL2U/NAME/J. JANSEN/REMI/USTD//FACTUUR 1/EREF/E2E-1")
+
+	assert.Equal(t, "SEPA OVERBOEKING", actual.Description)
+	assert.Equal(t, []string{"FACTUUR 1"}, actual.Title)
+	assert.Equal(t, Counterparty{Name: "J. JANSEN", IBAN: "NL44RABO0123456789", BIC: "RABONL2U"}, actual.Counterparty)
+	assert.Equal(t, map[string]string{"EREF": "E2E-1"}, actual.Sepa)
+	assert.Equal(t, "E2E-1", actual.Dutch.EndToEndReference)
+}
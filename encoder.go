@@ -0,0 +1,181 @@
+package mt940_converter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const maxLineLength = 65
+
+type Encoder struct {
+	writer io.Writer
+	opts   options
+}
+
+func NewEncoder(w io.Writer, opts ...Option) *Encoder {
+	return &Encoder{writer: w, opts: newOptions(opts)}
+}
+
+func (e *Encoder) Encode(stmt *Statement) error {
+	if stmt == nil {
+		return errors.New("no statement to encode")
+	}
+	text := FormatStatement(stmt, e.opts.getLineEnding())
+	if e.opts.encoding != "" {
+		encoded, err := EncodeCharset(text, e.opts.encoding)
+		if err != nil {
+			return err
+		}
+		text = encoded
+	}
+	_, err := io.WriteString(e.writer, text)
+	return err
+}
+
+func FormatStatement(stmt *Statement, lineEnding string) string {
+	var lines []string
+	add := func(tag string, value string) {
+		for i, line := range wrapLines(value) {
+			if i == 0 {
+				line = tag + line
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	add(referenceNumber, stmt.ReferenceNumber.Value)
+	if stmt.RelatedReference != nil {
+		add(relatedReference, stmt.RelatedReference.Value)
+	}
+	add(accountIdentification, FormatAccountIdentification(stmt.AccountIdentification))
+	if stmt.MessageType == MESSAGE_941 {
+		add(balanceStatementNumber, stmt.StatementNumber.Value)
+	} else {
+		add(statementNumber, stmt.StatementNumber.Value)
+	}
+	interim := stmt.MessageType == MESSAGE_941 || stmt.MessageType == MESSAGE_942
+	if interim && stmt.DateTimeIndication != nil {
+		add(dateTimeIndication, FormatDateTimeIndication(*stmt.DateTimeIndication))
+	}
+	if !isZeroBalance(stmt.OpeningBalance) {
+		add(balanceTag(stmt.OpeningBalance, openingBalance), FormatBalance(stmt.OpeningBalance))
+	}
+	for _, entry := range stmt.Transactions {
+		lines = append(lines, strings.Split(transaction+FormatTransactionStatement(entry.Statement), "\n")...)
+		if entry.Information.Info != "" {
+			add(transactionDescription, entry.Information.Info)
+		}
+	}
+	if interim && stmt.DebitEntries != nil {
+		add(debitEntries, FormatEntrySummary(*stmt.DebitEntries))
+	}
+	if interim && stmt.CreditEntries != nil {
+		add(creditEntries, FormatEntrySummary(*stmt.CreditEntries))
+	}
+	add(balanceTag(stmt.ClosingBalance, closingBalance), FormatBalance(stmt.ClosingBalance))
+	if stmt.AvailableBalance != nil {
+		add(availableBalance, FormatBalance(*stmt.AvailableBalance))
+	}
+	for _, balance := range stmt.ForwardBalances {
+		add(forwardBalance, FormatBalance(balance))
+	}
+	if stmt.Information != "" {
+		add(transactionDescription, stmt.Information)
+	}
+	lines = append(lines, messageEnd)
+	return strings.Join(lines, lineEnding) + lineEnding
+}
+
+func FormatAccountIdentification(account AccountIdentification) string {
+	if account.CountryIso == "DE" && germanAccountPattern.MatchString(account.Iban+account.Currency) {
+		return account.Iban + account.Currency
+	}
+	return account.CountryIso + account.Iban + account.Currency
+}
+
+func FormatBalance(balance Balance) string {
+	return string(balance.TransactionType) + FormatLongDate(balance.Date) + balance.Currency + FormatDecimal(balance.Amount)
+}
+
+func FormatTransactionStatement(stmt TransactionStatement) string {
+	var result strings.Builder
+	result.WriteString(FormatLongDate(stmt.ValueDate))
+	if stmt.EntryDate != nil {
+		result.WriteString(fmt.Sprintf("%02d%02d", stmt.EntryDate.Month, stmt.EntryDate.Day))
+	}
+	result.WriteString(string(stmt.TransactionType) + stmt.FundsCode + FormatDecimal(stmt.Amount))
+	result.WriteString(stmt.TransactionTypeCode + stmt.OwnerReference)
+	if stmt.BankReference != "" {
+		result.WriteString("//" + stmt.BankReference)
+	}
+	if stmt.SupplementaryDetails != "" {
+		result.WriteString("\n" + stmt.SupplementaryDetails)
+	}
+	return result.String()
+}
+
+func FormatDateTimeIndication(indication DateTimeIndication) string {
+	return fmt.Sprintf("%s%02d%02d%s", FormatLongDate(indication.Date), indication.Hour, indication.Minute, indication.UTCOffset)
+}
+
+func FormatEntrySummary(summary EntrySummary) string {
+	return fmt.Sprintf("%d%s%s", summary.Count, summary.Currency, FormatDecimal(summary.Amount))
+}
+
+func FormatLongDate(date LongDate) string {
+	return fmt.Sprintf("%02d%02d%02d", date.Year, date.Month, date.Day)
+}
+
+func FormatDecimal(amount MyDecimal) string {
+	value := decimal.Decimal(amount)
+	places := -value.Exponent()
+	if places < 0 {
+		places = 0
+	}
+	number := value.Abs().StringFixed(places)
+	if !strings.Contains(number, ".") {
+		return number + ","
+	}
+	return strings.Replace(number, ".", ",", 1)
+}
+
+func balanceTag(balance Balance, defaultTag string) string {
+	switch balance.BalanceType {
+	case OPENING:
+		return openingBalance
+	case INTERMEDIATE_OPENING:
+		return intermediateOpening
+	case CLOSING:
+		return closingBalance
+	case INTERMEDIATE_CLOSING:
+		return intermediateClosing
+	case AVAILABLE:
+		return availableBalance
+	case FORWARD_AVAILABLE:
+		return forwardBalance
+	default:
+		return defaultTag
+	}
+}
+
+func isZeroBalance(balance Balance) bool {
+	return balance.BalanceType == "" && balance.TransactionType == "" && balance.Currency == "" &&
+		balance.Date == (LongDate{}) && decimal.Decimal(balance.Amount).IsZero()
+}
+
+func wrapLines(value string) []string {
+	var result []string
+	for _, line := range strings.Split(normalizeLineEndings(value), "\n") {
+		runes := []rune(line)
+		for len(runes) > maxLineLength {
+			result = append(result, string(runes[:maxLineLength]))
+			runes = runes[maxLineLength:]
+		}
+		result = append(result, string(runes))
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package mt940_converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mt941Input = ":20:MT941REF\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28:00001/01\r\n" +
+	":13D:2306031215+0200\r\n" +
+	":60F:C230601EUR1000,00\r\n" +
+	":90D:1EUR2,50\r\n" +
+	":90C:1EUR449,77\r\n" +
+	":62F:C230603EUR1447,27\r\n" +
+	":64:C230603EUR1447,27\r\n" +
+	":65:C230604EUR1500,00\r\n" +
+	"-\r\n"
+
+const germanStatementInput = ":20:REF\r\n" +
+	":25:37040044/0532013000EUR\r\n" +
+	":28C:1\r\n" +
+	":60F:C230601EUR1000,00\r\n" +
+	":61:2306020602CN100,NTRFNONREF\r\n" +
+	":86:166?00SEPA-GUTSCHRIFT?20EREF+E2E-1?21SVWZ+Miete?32Max Mustermann\r\n" +
+	":62F:C230602EUR1100,00\r\n" +
+	"-\r\n"
+
+const maxTransactionLineInput = ":20:REF\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28C:1\r\n" +
+	":60F:C230601EUR1000,00\r\n" +
+	":61:2306020602RCN123456789012,34NTRFOWNERREFERENCE12//ABCDEFGHIJKLMNOP\r\n" +
+	"SUPPLEMENTARY DETAILS OF THE ENTRY\r\n" +
+	":62F:C230602EUR1000,00\r\n" +
+	"-\r\n"
+
+func TestEncoderRoundTripCase(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+		parse func(string, ...Option) (*Statement, error)
+		opts  []Option
+	}
+
+	testTable := []testCase{
+		{name: "Statement", input: statementInput, parse: ParseStatement},
+		{name: "Statement without information", input: secondStatementInput + "-\r\n", parse: ParseStatement},
+		{name: "First page", input: firstPageInput, parse: ParseStatement},
+		{name: "Second page", input: secondPageInput, parse: ParseStatement},
+		{name: "German statement", input: germanStatementInput, parse: ParseStatement},
+		{name: "Ukrainian statement in CP1251", input: ukrainianStatementInput, parse: ParseStatement, opts: []Option{WithEncoding(ENCODING_CP1251)}},
+		{name: "MT941", input: mt941Input, parse: ParseMT941},
+		{name: "Transaction line of maximum length", input: maxTransactionLineInput, parse: ParseStatement},
+	}
+
+	for _, test := range testTable {
+		expected, err := test.parse(test.input)
+		assert.Nil(t, err, test.name)
+
+		var output strings.Builder
+		assert.Nil(t, NewEncoder(&output, test.opts...).Encode(expected), test.name)
+		assert.Equal(t, test.input, output.String(), test.name)
+
+		actual, err := test.parse(output.String())
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, expected, actual, test.name)
+	}
+}
+
+func TestEncoderLineEndingCase(t *testing.T) {
+	expected, err := ParseStatement(statementInput)
+	assert.Nil(t, err)
+
+	var output strings.Builder
+	assert.Nil(t, NewEncoder(&output, WithLineEnding("\n")).Encode(expected))
+	assert.Equal(t, strings.ReplaceAll(statementInput, "\r\n", "\n"), output.String())
+}
+
+func TestEncoderLineWrappingCase(t *testing.T) {
+	stmt, err := ParseStatement(secondStatementInput)
+	assert.Nil(t, err)
+	stmt.Information = strings.Repeat("A", 70) + "\n" + strings.Repeat("B", 10)
+
+	var output strings.Builder
+	assert.Nil(t, NewEncoder(&output).Encode(stmt))
+	assert.Contains(t, output.String(), ":86:"+strings.Repeat("A", 65)+"\r\nAAAAA\r\nBBBBBBBBBB\r\n-\r\n")
+}
+
+func TestEncoderBalanceTagCase(t *testing.T) {
+	stmt, err := ParseStatement(secondStatementInput)
+	assert.Nil(t, err)
+	stmt.OpeningBalance = Balance{}
+	stmt.ClosingBalance.BalanceType = ""
+
+	var output strings.Builder
+	assert.Nil(t, NewEncoder(&output).Encode(stmt))
+	assert.NotContains(t, output.String(), openingBalance)
+	assert.Contains(t, output.String(), closingBalance+"C230604EUR1447,27\r\n")
+}
+
+func TestEncoderInterimFieldsCase(t *testing.T) {
+	stmt, err := ParseMT941(mt941Input)
+	assert.Nil(t, err)
+	stmt.MessageType = MESSAGE_940
+
+	var output strings.Builder
+	assert.Nil(t, NewEncoder(&output).Encode(stmt))
+	assert.NotContains(t, output.String(), dateTimeIndication)
+	assert.NotContains(t, output.String(), debitEntries)
+	assert.NotContains(t, output.String(), creditEntries)
+}
+
+func TestEncoderErrorCase(t *testing.T) {
+	stmt, err := ParseStatement(statementInput)
+	assert.Nil(t, err)
+
+	var output strings.Builder
+	assert.NotNil(t, NewEncoder(&output).Encode(nil))
+	assert.NotNil(t, NewEncoder(&output, WithEncoding(ENCODING_CP1251)).Encode(stmt))
+	assert.Empty(t, output.String())
+}
+
+func TestFormatDecimalCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult string
+	}
+
+	testTable := []testCase{
+		{name: "Decimal with two places", input: "1447,27", expectedResult: "1447,27"},
+		{name: "Decimal with trailing zero", input: "2,50", expectedResult: "2,50"},
+		{name: "Decimal without fraction", input: "100,", expectedResult: "100,"},
+		{name: "Zero", input: "0,", expectedResult: "0,"},
+		{name: "Decimal with thousands separators", input: "1,234,56", expectedResult: "1234,56"},
+	}
+
+	for _, test := range testTable {
+		amount, err := GetDecimal(test.input)
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.expectedResult, FormatDecimal(amount), test.name)
+	}
+}
@@ -0,0 +1,184 @@
+package mt940_converter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ErrorCode string
+
+const (
+	ErrTagNotFound         ErrorCode = "TAG_NOT_FOUND"
+	ErrMissingTag          ErrorCode = "MISSING_TAG"
+	ErrIncorrectLength     ErrorCode = "INCORRECT_LENGTH"
+	ErrIncorrectFormat     ErrorCode = "INCORRECT_FORMAT"
+	ErrIncorrectCharacter  ErrorCode = "INCORRECT_CHARACTER"
+	ErrIncorrectDate       ErrorCode = "INCORRECT_DATE"
+	ErrIncorrectAmount     ErrorCode = "INCORRECT_AMOUNT"
+	ErrIncorrectType       ErrorCode = "INCORRECT_TYPE"
+	ErrIncorrectEnvelope   ErrorCode = "INCORRECT_ENVELOPE"
+	ErrUnsupportedMessage  ErrorCode = "UNSUPPORTED_MESSAGE"
+	ErrUnsupportedEncoding ErrorCode = "UNSUPPORTED_ENCODING"
+)
+
+const maxSnippetLength = 65
+
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+type ParseError struct {
+	MessageIndex int
+	Transaction  int
+	Tag          string
+	Line         int
+	Column       int
+	Snippet      string
+	Code         ErrorCode
+	Description  string
+	Err          error
+}
+
+func (e *ParseError) Error() string {
+	var location []string
+	if e.MessageIndex > 0 {
+		location = append(location, fmt.Sprintf("message %v", e.MessageIndex))
+	}
+	if e.Transaction > 0 {
+		location = append(location, fmt.Sprintf("transaction %v", e.Transaction))
+	}
+	if e.Line > 0 {
+		location = append(location, fmt.Sprintf("line %v", e.Line))
+	}
+	if e.Column > 0 {
+		location = append(location, fmt.Sprintf("column %v", e.Column))
+	}
+	if e.Tag != "" {
+		location = append(location, e.Tag)
+	}
+
+	var result strings.Builder
+	if len(location) > 0 {
+		result.WriteString("[" + strings.Join(location, " ") + "] ")
+	}
+	result.WriteString(e.Description)
+	if e.Err != nil {
+		result.WriteString(". Error: " + e.Err.Error())
+	}
+	return result.String()
+}
+
+func (e *ParseError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == e.Code
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	var result []string
+	for _, err := range e {
+		result = append(result, err.Error())
+	}
+	return strings.Join(result, "; ")
+}
+
+func (e ParseErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func newParseError(code ErrorCode, snippet string, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Code:        code,
+		Snippet:     getSnippet(snippet),
+		Description: fmt.Sprintf(format, args...),
+	}
+}
+
+func newTagNotFoundError(input string, name string, tag string) *ParseError {
+	err := newParseError(ErrTagNotFound, input, "no %s tag found. Expected tag: %s", name, tag)
+	err.Tag = tag
+	err.Column = 1
+	return err
+}
+
+func newFieldError(code ErrorCode, tag string, value string, offset int, format string, args ...interface{}) *ParseError {
+	err := newParseError(code, value, format, args...)
+	err.Tag = tag
+	err.Column = len(tag) + offset + 1
+	return err
+}
+
+func wrapFieldError(err error, tag string, value string, offset int, format string, args ...interface{}) *ParseError {
+	code := ErrIncorrectFormat
+	var cause *ParseError
+	if errors.As(err, &cause) {
+		code = cause.Code
+	}
+	result := newFieldError(code, tag, value, offset, format, args...)
+	if cause != nil && cause.Tag == tag && cause.Column > 0 {
+		result.Column = cause.Column
+	}
+	result.Err = err
+	return result
+}
+
+func asParseError(err error) *ParseError {
+	var parseError *ParseError
+	if errors.As(err, &parseError) {
+		return parseError
+	}
+	result := newParseError(ErrIncorrectFormat, "", "%v", err)
+	result.Err = err
+	return result
+}
+
+func withPosition(err error, f field) error {
+	var parseError *ParseError
+	if !errors.As(err, &parseError) {
+		return err
+	}
+	parseError.Line = f.Line
+	if parseError.Tag == "" {
+		parseError.Tag = f.Tag
+	}
+	if parseError.Column == 0 {
+		parseError.Column = 1
+	}
+	return parseError
+}
+
+func withTransaction(err error, index int) error {
+	parseError := asParseError(err)
+	parseError.Transaction = index
+	return parseError
+}
+
+func withLineOffset(err error, offset int) error {
+	var parseError *ParseError
+	if offset > 0 && errors.As(err, &parseError) && parseError.Line > 0 {
+		parseError.Line += offset
+	}
+	return err
+}
+
+func getSnippet(input string) string {
+	line := input
+	if index := strings.IndexAny(line, "\r\n"); index >= 0 {
+		line = line[:index]
+	}
+	if len(line) > maxSnippetLength {
+		return line[:maxSnippetLength]
+	}
+	return line
+}
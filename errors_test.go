@@ -0,0 +1,107 @@
+package mt940_converter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseErrorCase(t *testing.T) {
+	type testCase struct {
+		name     string
+		parse    func() error
+		expected ParseError
+	}
+
+	testTable := []testCase{
+		{
+			name: "Reference number too long",
+			parse: func() error {
+				_, err := GetReferenceNumber(":20:referenceNumber12\r\n")
+				return err
+			},
+			expected: ParseError{Tag: referenceNumber, Column: 21, Snippet: "referenceNumber12", Code: ErrIncorrectLength},
+		},
+		{
+			name: "Balance tag not found",
+			parse: func() error {
+				_, err := GetBalance(":60F:C120216UAH73447,91\r\n", CLOSING)
+				return err
+			},
+			expected: ParseError{Tag: closingBalance, Column: 1, Snippet: ":60F:C120216UAH73447,91", Code: ErrTagNotFound},
+		},
+		{
+			name: "Statement with incorrect closing balance amount",
+			parse: func() error {
+				_, err := ParseStatement(strings.Replace(statementInput, "EUR1447,27\r\n", "EUR14x7,27\r\n", 1))
+				return err
+			},
+			expected: ParseError{Tag: closingBalance, Line: 12, Column: 16, Snippet: "C230603EUR14x7,27", Code: ErrIncorrectAmount},
+		},
+		{
+			name: "Statement with incorrect transaction",
+			parse: func() error {
+				_, err := ParseStatement(strings.Replace(statementInput, "2306030603CN449,77", "2306030603XN449,77", 1))
+				return err
+			},
+			expected: ParseError{Tag: transaction, Line: 9, Column: 5, Snippet: "2306030603XN449,77NTRFSP300//BR05012139000001", Code: ErrIncorrectFormat},
+		},
+		{
+			name: "Wrapped statement with incorrect opening balance date",
+			parse: func() error {
+				_, err := ParseStatement(strings.Replace(wrappedStatementInput, ":60F:C230603", ":60F:C2306A3", 1))
+				return err
+			},
+			expected: ParseError{Tag: openingBalance, Line: 5, Column: 7, Snippet: "C2306A3EUR1447,27", Code: ErrIncorrectDate},
+		},
+		{
+			name: "Statement without closing balance",
+			parse: func() error {
+				_, err := ParseStatement(":20:STARTUMS\r\n:25:NL17RABO6064103256EUR\r\n:28C:1\r\n:60F:C230601EUR1000,00\r\n-\r\n")
+				return err
+			},
+			expected: ParseError{Code: ErrMissingTag},
+		},
+		{
+			name: "Decoder with incorrect second message",
+			parse: func() error {
+				decoder := NewDecoder(strings.NewReader(statementInput + strings.Replace(secondStatementInput, ":28C:00002", ":28C:0000002", 1)))
+				for decoder.Next() {
+				}
+				return decoder.Err()
+			},
+			expected: ParseError{MessageIndex: 2, Tag: statementNumber, Line: 18, Column: 11, Snippet: "0000002", Code: ErrIncorrectLength},
+		},
+	}
+
+	for _, test := range testTable {
+		err := test.parse()
+		assert.True(t, errors.Is(err, test.expected.Code), test.name)
+
+		var actual *ParseError
+		if assert.True(t, errors.As(err, &actual), test.name) {
+			assert.Equal(t, test.expected.MessageIndex, actual.MessageIndex, test.name)
+			assert.Equal(t, test.expected.Tag, actual.Tag, test.name)
+			assert.Equal(t, test.expected.Line, actual.Line, test.name)
+			assert.Equal(t, test.expected.Column, actual.Column, test.name)
+			assert.Equal(t, test.expected.Snippet, actual.Snippet, test.name)
+		}
+	}
+}
+
+func TestParseErrorMessageCase(t *testing.T) {
+	err := &ParseError{
+		MessageIndex: 2,
+		Tag:          openingBalance,
+		Line:         5,
+		Column:       7,
+		Code:         ErrIncorrectDate,
+		Description:  "cannot parse date",
+		Err:          newParseError(ErrIncorrectDate, "2306A3", "incorrect date format"),
+	}
+
+	assert.Equal(t, "[message 2 line 5 column 7 :60F:] cannot parse date. Error: incorrect date format", err.Error())
+	assert.False(t, errors.Is(err, ErrIncorrectAmount))
+}
@@ -0,0 +1,235 @@
+package mt940_converter
+
+import (
+	"strings"
+)
+
+const (
+	basicHeaderBlock       = "1"
+	applicationHeaderBlock = "2"
+	userHeaderBlock        = "3"
+	textBlock              = "4"
+	trailerBlock           = "5"
+	messageUserReference   = "108"
+	checksumTrailer        = "CHK"
+	authenticationTrailer  = "MAC"
+)
+
+type BasicHeader struct {
+	ApplicationID   string
+	ServiceID       string
+	LogicalTerminal string
+	SessionNumber   string
+	SequenceNumber  string
+}
+
+type ApplicationHeader struct {
+	Direction             string
+	MessageType           string
+	Address               string
+	Priority              string
+	DeliveryMonitoring    string
+	ObsolescencePeriod    string
+	InputTime             string
+	MessageInputReference string
+	OutputDate            string
+	OutputTime            string
+}
+
+type UserHeader struct {
+	Fields               map[string]string
+	MessageUserReference string
+}
+
+type Trailer struct {
+	Fields   map[string]string
+	Checksum string
+	MAC      string
+}
+
+type FinEnvelope struct {
+	BasicHeader       BasicHeader
+	ApplicationHeader *ApplicationHeader
+	UserHeader        *UserHeader
+	Trailer           *Trailer
+}
+
+type FinMessage struct {
+	Envelope *FinEnvelope
+	Text     string
+}
+
+func (e FinEnvelope) SenderBIC() string {
+	if e.ApplicationHeader != nil && e.ApplicationHeader.Direction == "O" && len(e.ApplicationHeader.MessageInputReference) >= 18 {
+		return bicFromAddress(e.ApplicationHeader.MessageInputReference[6:18])
+	}
+	return bicFromAddress(e.BasicHeader.LogicalTerminal)
+}
+
+func (e FinEnvelope) ReceiverBIC() string {
+	if e.ApplicationHeader != nil && e.ApplicationHeader.Direction == "I" {
+		return bicFromAddress(e.ApplicationHeader.Address)
+	}
+	return bicFromAddress(e.BasicHeader.LogicalTerminal)
+}
+
+func (e FinEnvelope) MessageType() string {
+	if e.ApplicationHeader == nil {
+		return ""
+	}
+	return e.ApplicationHeader.MessageType
+}
+
+func bicFromAddress(address string) string {
+	if len(address) != 12 {
+		return address
+	}
+	return address[:8] + address[9:]
+}
+
+func IsFinMessage(input string) bool {
+	return strings.HasPrefix(strings.TrimSpace(input), "{"+basicHeaderBlock+":")
+}
+
+func ParseFinMessage(input string) (*FinMessage, error) {
+	blocks, err := getBlocks(strings.TrimSpace(input))
+	if err != nil {
+		return nil, err
+	}
+	basic, ok := blocks[basicHeaderBlock]
+	if !ok {
+		return nil, newParseError(ErrIncorrectEnvelope, input, "no basic header block found. Expected block: {%s:", basicHeaderBlock)
+	}
+	text, ok := blocks[textBlock]
+	if !ok {
+		return nil, newParseError(ErrIncorrectEnvelope, input, "no text block found. Expected block: {%s:", textBlock)
+	}
+
+	basicHeader, err := GetBasicHeader(basic)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &FinEnvelope{BasicHeader: *basicHeader}
+	if application, ok := blocks[applicationHeaderBlock]; ok {
+		envelope.ApplicationHeader, err = GetApplicationHeader(application)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if user, ok := blocks[userHeaderBlock]; ok {
+		fields, err := getBlocks(user)
+		if err != nil {
+			return nil, wrapEnvelopeError(err, user, "cannot parse user header")
+		}
+		envelope.UserHeader = &UserHeader{
+			Fields:               fields,
+			MessageUserReference: fields[messageUserReference],
+		}
+	}
+	if trailer, ok := blocks[trailerBlock]; ok {
+		fields, err := getBlocks(trailer)
+		if err != nil {
+			return nil, wrapEnvelopeError(err, trailer, "cannot parse trailer")
+		}
+		envelope.Trailer = &Trailer{
+			Fields:   fields,
+			Checksum: fields[checksumTrailer],
+			MAC:      fields[authenticationTrailer],
+		}
+	}
+
+	text = strings.TrimSuffix(strings.TrimSpace(text), messageEnd)
+	return &FinMessage{Envelope: envelope, Text: strings.TrimLeft(text, "\r\n")}, nil
+}
+
+func GetBasicHeader(input string) (*BasicHeader, error) {
+	if len(input) != 25 {
+		return nil, newParseError(ErrIncorrectEnvelope, input, "the basic header character size is incorrect. Size: %v", len(input))
+	}
+	return &BasicHeader{
+		ApplicationID:   input[0:1],
+		ServiceID:       input[1:3],
+		LogicalTerminal: input[3:15],
+		SessionNumber:   input[15:19],
+		SequenceNumber:  input[19:25],
+	}, nil
+}
+
+func GetApplicationHeader(input string) (*ApplicationHeader, error) {
+	if len(input) < 4 {
+		return nil, newParseError(ErrIncorrectEnvelope, input, "the application header character size is incorrect. Size: %v", len(input))
+	}
+	header := &ApplicationHeader{
+		Direction:   input[0:1],
+		MessageType: input[1:4],
+	}
+	switch header.Direction {
+	case "I":
+		if len(input) < 16 || len(input) > 21 {
+			return nil, newParseError(ErrIncorrectEnvelope, input, "the input application header character size is incorrect. Size: %v", len(input))
+		}
+		header.Address = input[4:16]
+		rest := input[16:]
+		if len(rest) > 0 {
+			header.Priority, rest = rest[:1], rest[1:]
+		}
+		if len(rest) > 0 {
+			header.DeliveryMonitoring, rest = rest[:1], rest[1:]
+		}
+		header.ObsolescencePeriod = rest
+	case "O":
+		if len(input) < 46 || len(input) > 47 {
+			return nil, newParseError(ErrIncorrectEnvelope, input, "the output application header character size is incorrect. Size: %v", len(input))
+		}
+		header.InputTime = input[4:8]
+		header.MessageInputReference = input[8:36]
+		header.OutputDate = input[36:42]
+		header.OutputTime = input[42:46]
+		header.Priority = input[46:]
+	default:
+		return nil, newParseError(ErrIncorrectEnvelope, input, "incorrect application header direction: %v", header.Direction)
+	}
+	return header, nil
+}
+
+func getBlocks(input string) (map[string]string, error) {
+	blocks := make(map[string]string)
+	for i := 0; i < len(input); {
+		switch input[i] {
+		case '{':
+		case '\r', '\n', ' ':
+			i++
+			continue
+		default:
+			return nil, newParseError(ErrIncorrectEnvelope, input[i:], "unexpected character %q at position %v", input[i], i)
+		}
+		separator := strings.IndexByte(input[i:], ':')
+		if separator < 0 {
+			return nil, newParseError(ErrIncorrectEnvelope, input[i:], "no block identifier found at position %v", i)
+		}
+		id := input[i+1 : i+separator]
+		start := i + separator + 1
+		depth := 1
+		end := start
+		for ; end < len(input) && depth > 0; end++ {
+			switch input[end] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+		if depth != 0 {
+			return nil, newParseError(ErrIncorrectEnvelope, input[i:], "block {%s: is not terminated", id)
+		}
+		blocks[id] = input[start : end-1]
+		i = end
+	}
+	return blocks, nil
+}
+
+func wrapEnvelopeError(err error, input string, description string) *ParseError {
+	result := newParseError(ErrIncorrectEnvelope, input, description)
+	result.Err = err
+	return result
+}
@@ -0,0 +1,111 @@
+package mt940_converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFinMessageCase(t *testing.T) {
+	type testCase struct {
+		name             string
+		input            string
+		expectedEnvelope *FinEnvelope
+		expectedText     string
+		expectedSender   string
+		expectedReceiver string
+		hasError         bool
+	}
+
+	testTable := []testCase{
+		{
+			name: "Output message with all blocks",
+			input: "{1:F01BANKBEBBAXXX0000000000}{2:O9401200230603BANKDEFFAXXX00000000002306031200N}{3:{108:MUR12345}}{4:\r\n" +
+				secondStatementInput + "-}{5:{CHK:123456789ABC}{MAC:ABCD1234}}",
+			expectedEnvelope: &FinEnvelope{
+				BasicHeader: BasicHeader{
+					ApplicationID:   "F",
+					ServiceID:       "01",
+					LogicalTerminal: "BANKBEBBAXXX",
+					SessionNumber:   "0000",
+					SequenceNumber:  "000000",
+				},
+				ApplicationHeader: &ApplicationHeader{
+					Direction:             "O",
+					MessageType:           "940",
+					Priority:              "N",
+					InputTime:             "1200",
+					MessageInputReference: "230603BANKDEFFAXXX0000000000",
+					OutputDate:            "230603",
+					OutputTime:            "1200",
+				},
+				UserHeader: &UserHeader{
+					Fields:               map[string]string{"108": "MUR12345"},
+					MessageUserReference: "MUR12345",
+				},
+				Trailer: &Trailer{
+					Fields:   map[string]string{"CHK": "123456789ABC", "MAC": "ABCD1234"},
+					Checksum: "123456789ABC",
+					MAC:      "ABCD1234",
+				},
+			},
+			expectedText:     secondStatementInput,
+			expectedSender:   "BANKDEFFXXX",
+			expectedReceiver: "BANKBEBBXXX",
+		},
+		{
+			name:  "Input message without optional blocks",
+			input: "{1:F01BANKBEBBAXXX0000000000}{2:I940BANKDEFFXXXXN}{4:\r\n" + secondStatementInput + "-}",
+			expectedEnvelope: &FinEnvelope{
+				BasicHeader: BasicHeader{
+					ApplicationID:   "F",
+					ServiceID:       "01",
+					LogicalTerminal: "BANKBEBBAXXX",
+					SessionNumber:   "0000",
+					SequenceNumber:  "000000",
+				},
+				ApplicationHeader: &ApplicationHeader{
+					Direction:   "I",
+					MessageType: "940",
+					Address:     "BANKDEFFXXXX",
+					Priority:    "N",
+				},
+			},
+			expectedText:     secondStatementInput,
+			expectedSender:   "BANKBEBBXXX",
+			expectedReceiver: "BANKDEFFXXX",
+		},
+		{name: "Text block is missing", input: "{1:F01BANKBEBBAXXX0000000000}{2:I940BANKDEFFXXXXN}", hasError: true},
+		{name: "Basic header is incorrect", input: "{1:F01BANK}{4:\r\n:20:X\r\n-}", hasError: true},
+		{name: "Block is not terminated", input: "{1:F01BANKBEBBAXXX0000000000}{4:\r\n:20:X\r\n-", hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := ParseFinMessage(test.input)
+
+		if test.hasError {
+			assert.Nil(t, actual, test.name)
+			assert.NotNil(t, err, test.name)
+			continue
+		}
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.expectedEnvelope, actual.Envelope, test.name)
+		assert.Equal(t, test.expectedText, actual.Text, test.name)
+		assert.Equal(t, test.expectedSender, actual.Envelope.SenderBIC(), test.name)
+		assert.Equal(t, test.expectedReceiver, actual.Envelope.ReceiverBIC(), test.name)
+	}
+}
+
+func TestParseStatementWithEnvelopeCase(t *testing.T) {
+	bare, err := ParseStatement(secondStatementInput)
+	assert.Nil(t, err)
+	assert.Nil(t, bare.Envelope)
+
+	wrapped, err := ParseStatement(wrappedStatementInput)
+	assert.Nil(t, err)
+	assert.Equal(t, "940", wrapped.Envelope.MessageType())
+	assert.Equal(t, "BANKDEFFXXX", wrapped.Envelope.SenderBIC())
+
+	wrapped.Envelope = nil
+	assert.Equal(t, bare, wrapped)
+}
@@ -0,0 +1,381 @@
+package mt940_converter
+
+import (
+	"strings"
+	"testing"
+)
+
+const camtStatementInput = ":20:REF\r\n" +
+	":25:37040044/0532013000EUR\r\n" +
+	":28C:1\r\n" +
+	":13D:2306031215+0000\r\n" +
+	":60F:C230601EUR1000,00\r\n" +
+	":61:2306020602C100,NTRFE2E-1\r\n" +
+	":86:166?00SEPA-GUTSCHRIFT?20EREF+E2E-1?21SVWZ+Miete?32Max Mustermann\r\n" +
+	":62F:C230602EUR1100,00\r\n" +
+	"-\r\n"
+
+const unresolvableEntryDateInput = ":20:REF\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28C:1\r\n" +
+	":60F:C230301EUR1000,00\r\n" +
+	":61:2303010229CN100,NTRFNONREF\r\n" +
+	":62F:C230301EUR1100,00\r\n" +
+	"-\r\n"
+
+var transactionSeeds = []string{
+	":61:0710091009DN2,50NCHGNONREF//BR07282102000059\n824-OPŁ. ZA PRZEL. ELIXIR MT\n:86:824 OPŁATA ZA PRZELEW ELIXIR; TNR: 145271016138274.040001\n",
+	":61:0501120112DN449,77NTRFSP300//BR05012139000001\n944-PRZEL.KRAJ.WYCH.MT.ELX\n:86:944 CompanyNet Przelew krajowy; na rach.: 35109010560000000006093440; dla: PHU Test ul.Dolna\n1 00-950 Warszawa; tyt.: fv 100/2007; TNR: 145271016138277.020002",
+	":61:2306040604D1,89S07397301056237\n:86:073\n:86:073~00VE02\n~20PàatnoòÜ kart• 02.06.2023 \n~21Nr karty 4246xx4970~22\n~23~24\n~25\n~3010500031~311915031/19730\n~32BOLT.EU/R/2306021457      ~33Tallinn \n~34073",
+	":61:",
+	":61::86:",
+}
+
+func addSeeds(f *testing.F, seeds ...string) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+}
+
+func addModeSeeds(f *testing.F, seeds ...string) {
+	for _, seed := range seeds {
+		f.Add(seed, false)
+		f.Add(seed, true)
+	}
+}
+
+func fuzzOptions(lenient bool) []Option {
+	if lenient {
+		return []Option{WithMode(LENIENT_MODE)}
+	}
+	return nil
+}
+
+func statementSeeds() []string {
+	seeds := []string{statementInput, secondStatementInput, germanStatementInput, ukrainianStatementInput, wrappedStatementInput,
+		firstPageInput, secondPageInput, mt941Input, mt942Input, maxTransactionLineInput, camtStatementInput, unresolvableEntryDateInput, ""}
+	camt053, _ := ParseCamt053(germanCamt053Output)
+	camt052, _ := ParseCamt052(camt052Input)
+	for _, stmt := range append(camt053, camt052...) {
+		var output strings.Builder
+		if err := NewEncoder(&output).Encode(&stmt); err == nil {
+			seeds = append(seeds, output.String())
+		}
+	}
+	return seeds
+}
+
+func FuzzGetLongDate(f *testing.F) {
+	addSeeds(f, "020222", "032211", "02010522222", "1111")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetLongDate(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetShortDate(f *testing.F) {
+	addSeeds(f, "0222", "2211", "02010522222", "111")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetShortDate(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetDecimal(f *testing.F) {
+	addSeeds(f, "73447,91", "734488877,91", "2,50", "100,", "1,234,56", "12a,00")
+	f.Fuzz(func(t *testing.T, input string) {
+		_, _ = GetDecimal(input)
+	})
+}
+
+func FuzzGetFirstNChars(f *testing.F) {
+	f.Add("NL17RABO6064103256EUR", 3)
+	f.Add("NL", 2)
+	f.Add("", 1)
+	f.Fuzz(func(t *testing.T, input string, number int) {
+		_ = GetFirstNChars(input, number)
+	})
+}
+
+func FuzzGetLastNChars(f *testing.F) {
+	f.Add("NL17RABO6064103256EUR", 3)
+	f.Add("NL", 2)
+	f.Add("", 1)
+	f.Fuzz(func(t *testing.T, input string, number int) {
+		_ = GetLastNChars(input, number)
+	})
+}
+
+func FuzzGetReferenceNumber(f *testing.F) {
+	addSeeds(f, ":20:referenceNumber1\r\n", ":20:\r\n", ":20:referenceNumber12\r\n", ":0:testReferenceNumber\r\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetReferenceNumber(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetRelatedReference(f *testing.F) {
+	addSeeds(f, ":21:relatedReference\r\n", ":21:\r\n", ":21:relatedReference12\r\n", ":0:relatedReference\r\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetRelatedReference(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetAccountIdentification(f *testing.F) {
+	addSeeds(f, ":25:NL17RABO6064103256EUR\r\n", ":25:NL17RABO6064103256\r\n", ":25:\r\n", "NL17RABO6064103256EUR\r\n",
+		":25:NI81CCSF6843126715474931687323111UAH\r\n", ":25:EUR\r\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetAccountIdentification(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetStatementNumber(f *testing.F) {
+	addSeeds(f, ":28C:44444\r\n", ":28C:\r\n", ":28C:555555\r\n", ":28:01234\r\n", ":28C:00001/001\r\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetStatementNumber(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetBalance(f *testing.F) {
+	addSeeds(f, ":60F:C120216UAH73447,91\r\n", ":62F:D110122PLN734488877,91\r\n", ":64:C120216UAH73447,91\r\n",
+		":65:C120216UAH73447,91\r\n", ":60M:C230602EUR997,50\r\n", ":60F:\r\n", ":60F:C\r\n", ":60F:C120216UAH73447,9wwww\r\n")
+	balanceTypes := []BalanceType{OPENING, CLOSING, AVAILABLE, FORWARD_AVAILABLE, INTERMEDIATE_OPENING, INTERMEDIATE_CLOSING}
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, balanceType := range balanceTypes {
+			if result, err := GetBalance(input, balanceType); err == nil && result == nil {
+				t.Errorf("no result and no error for %q", input)
+			}
+		}
+	})
+}
+
+func FuzzGetTransactions(f *testing.F) {
+	addModeSeeds(f, transactionSeeds...)
+	f.Fuzz(func(t *testing.T, input string, lenient bool) {
+		_, _ = GetTransactions(input, fuzzOptions(lenient)...)
+	})
+}
+
+func FuzzGetTransactionInfo(f *testing.F) {
+	addSeeds(f, transactionSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		_ = GetTransactionInfo(input)
+	})
+}
+
+func FuzzGetStatement(f *testing.F) {
+	addSeeds(f, "230602C100,NTRFINV-2023-001\r\n", "2306020603RDR1234,56FMSCNONREF//8327000090031789\r\nCard reversal\r\n:86:info",
+		"230602C100,\r\n", "230602100,NTRFNONREF\r\n", "2303010229CN449,77NTRFSP300\r\n", "")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetStatement(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetFloorLimit(f *testing.F) {
+	addSeeds(f, ":34F:EUR100,00\r\n", ":34F:EURD100,00\r\n", ":34F:EURC250,00\r\n", ":34F:\r\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetFloorLimit(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetDateTimeIndication(f *testing.F) {
+	addSeeds(f, ":13D:2306031215+0200\r\n", ":13D:2306032460+0200\r\n", ":13D:2306031215+1500\r\n", ":13D:\r\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetDateTimeIndication(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzGetEntrySummary(f *testing.F) {
+	addSeeds(f, ":90D:12EUR100,00\r\n", ":90C:3EUR100,00\r\n", ":90C:0EUR0,\r\n", ":90D:\r\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := GetEntrySummary(input, DEBIT); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+var informationSeeds = []string{
+	"824 OPŁATA ZA PRZELEW ELIXIR",
+	"020?00PRZELEW KRAJOWY?10123456?20Faktura VAT?21 FV/1/2023?27ACME SP. Z O.O.\n?28?29UL. DLUGA 1 WARSZAWA?3010500031?38PL61109010140000071219812874",
+	"166?00SEPA-UEBERWEISUNG?109310?20EREF+E2E-REF-0001?21KREF+NOTPROVIDED?22SVWZ+Rechnung",
+	"/TRTP/SEPA OVERBOEKING/IBAN/NL44RABO0123456789/BIC/RABONL2U/NAME/J. JANSEN/REMI/USTD//FACTUUR 2023/\n0042/EREF/NOTPROVIDED/XYZ/extra value",
+	"Оплата за товар згідно рах. №15\nUA223052990000026001234567890 ТОВ \"РОМАШКА\" ЄДРПОУ 12345678",
+	"/", "//", "/A/", "123?",
+}
+
+func FuzzParsePolishInformation(f *testing.F) {
+	addSeeds(f, informationSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		_, _ = ParsePolishInformation(input)
+	})
+}
+
+func FuzzParseGermanInformation(f *testing.F) {
+	addSeeds(f, informationSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		_, _ = ParseGermanInformation(input)
+	})
+}
+
+func FuzzParseDutchInformation(f *testing.F) {
+	addSeeds(f, informationSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		_, _ = ParseDutchInformation(input)
+	})
+}
+
+func FuzzParseUkrainianInformation(f *testing.F) {
+	addSeeds(f, informationSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		_, _ = ParseUkrainianInformation(input)
+	})
+}
+
+func FuzzParseInformation(f *testing.F) {
+	addSeeds(f, informationSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		_ = parseInformation(input)
+	})
+}
+
+func FuzzValidateField(f *testing.F) {
+	addSeeds(f, ":20:STARTUMS", ":28C:00001/001", ":60F:C230601EUR1000,00", ":61:2306020602DN2,50NCHGNONREF//BR07282102000059\nCard",
+		":86:824 OPŁATA ZA PRZELEW ELIXIR", ":86:", ":13D:2306031215+0200")
+	f.Fuzz(func(t *testing.T, input string) {
+		if tag := tagPattern.FindString(input); tag != "" {
+			_ = ValidateField(tag, input[len(tag):])
+		}
+	})
+}
+
+func FuzzDetectEncoding(f *testing.F) {
+	for _, input := range encodedTexts {
+		f.Add(input)
+	}
+	addSeeds(f, polishText, utf8BOM+ukrainianText, "\xFF", "")
+	f.Fuzz(func(t *testing.T, input string) {
+		_ = DetectEncoding(input)
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, input := range encodedTexts {
+		f.Add(input)
+	}
+	addSeeds(f, polishText, utf8BOM+ukrainianText, "\xFF", "")
+	f.Fuzz(func(t *testing.T, input string) {
+		for encoding := range charsetTables {
+			_, _ = Decode(input, encoding)
+		}
+		_, _ = Decode(input, ENCODING_UTF8)
+	})
+}
+
+func FuzzParseFinMessage(f *testing.F) {
+	addSeeds(f, wrappedStatementInput, "{1:F01BANKBEBBAXXX0000000000}{2:I940BANKDEFFXXXXN}", "{1:F01BANK}{4:\r\n:20:X\r\n-}",
+		"{1:F01BANKBEBBAXXX0000000000}{4:\r\n:20:X\r\n-", "{", "{}", "{:}")
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := ParseFinMessage(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzParseStatement(f *testing.F) {
+	addModeSeeds(f, statementSeeds()...)
+	f.Fuzz(func(t *testing.T, input string, lenient bool) {
+		if result, err := ParseStatement(input, fuzzOptions(lenient)...); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzParseMT941(f *testing.F) {
+	addModeSeeds(f, statementSeeds()...)
+	f.Fuzz(func(t *testing.T, input string, lenient bool) {
+		if result, err := ParseMT941(input, fuzzOptions(lenient)...); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzParseMT950(f *testing.F) {
+	addModeSeeds(f, statementSeeds()...)
+	f.Fuzz(func(t *testing.T, input string, lenient bool) {
+		if result, err := ParseMT950(input, fuzzOptions(lenient)...); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzParseMT942(f *testing.F) {
+	addModeSeeds(f, statementSeeds()...)
+	f.Fuzz(func(t *testing.T, input string, lenient bool) {
+		if result, err := ParseMT942(input, fuzzOptions(lenient)...); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzDecoder(f *testing.F) {
+	addSeeds(f, statementInput+secondStatementInput, wrappedStatementInput+"\r\n{5:{CHK:123456789ABC}}\r\n"+wrappedStatementInput,
+		mt941Input+mt942Input+statementInput, camtStatementInput, "\r", "-}")
+	f.Fuzz(func(t *testing.T, input string) {
+		decoder := NewDecoder(strings.NewReader(input))
+		for decoder.Next() {
+			if decoder.Statement() == nil && decoder.MT942() == nil {
+				t.Errorf("no message for %q", input)
+			}
+		}
+	})
+}
+
+var camtSeeds = []string{
+	germanCamt053Output,
+	camt052Input,
+	camt054Input,
+	"<Document/>",
+	"<Document><BkToCstmrStmt><Stmt><Ntry/></Stmt></BkToCstmrStmt></Document>",
+}
+
+func FuzzParseCamt053(f *testing.F) {
+	addSeeds(f, camtSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := ParseCamt053(input); err == nil && len(result) == 0 {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzParseCamt052(f *testing.F) {
+	addSeeds(f, camtSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := ParseCamt052(input); err == nil && len(result) == 0 {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
+
+func FuzzParseCamt054(f *testing.F) {
+	addSeeds(f, camtSeeds...)
+	f.Fuzz(func(t *testing.T, input string) {
+		if result, err := ParseCamt054(input); err == nil && result == nil {
+			t.Errorf("no result and no error for %q", input)
+		}
+	})
+}
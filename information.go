@@ -0,0 +1,174 @@
+package mt940_converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Counterparty struct {
+	Name     string
+	IBAN     string
+	BIC      string
+	Account  string
+	BankCode string
+	Address  string
+	TaxID    string
+}
+
+type Subfield struct {
+	Key   string
+	Value string
+}
+
+var (
+	subfieldsPattern   = regexp.MustCompile(`^([0-9]{3})([?~])[0-9]{2}`)
+	sepaKeywordPattern = regexp.MustCompile(`(EREF|KREF|MREF|CRED|DEBT|SVWZ|ABWA|ABWE|IBAN|BIC|COAM|OAMT|SQTP|PURP)\+`)
+	ibanPattern        = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{1,30}$`)
+)
+
+var polishSubfields = struct {
+	description []string
+	title       []string
+	name        []string
+	address     []string
+	bankCode    []string
+	account     []string
+	iban        []string
+}{
+	description: []string{"00"},
+	title:       []string{"20", "21", "22", "23", "24", "25", "26"},
+	name:        []string{"27", "28", "32", "33"},
+	address:     []string{"29", "60", "61", "62", "63"},
+	bankCode:    []string{"30"},
+	account:     []string{"31"},
+	iban:        []string{"38"},
+}
+
+var germanSubfields = struct {
+	postingText []string
+	primanota   []string
+	purpose     []string
+	bankCode    []string
+	account     []string
+	name        []string
+}{
+	postingText: []string{"00"},
+	primanota:   []string{"10"},
+	purpose:     []string{"20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "60", "61", "62", "63"},
+	bankCode:    []string{"30"},
+	account:     []string{"31"},
+	name:        []string{"32", "33"},
+}
+
+func ParsePolishInformation(input string) (*TransactionInformation, error) {
+	code, subfields, err := getSubfields(input)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionInformation{
+		Info:        input,
+		Code:        code,
+		Description: joinSubfields(subfields, polishSubfields.description, ""),
+		Title:       subfieldLines(subfields, polishSubfields.title),
+		Counterparty: Counterparty{
+			Name:     joinSubfields(subfields, polishSubfields.name, " "),
+			IBAN:     joinSubfields(subfields, polishSubfields.iban, ""),
+			Account:  joinSubfields(subfields, polishSubfields.account, ""),
+			BankCode: joinSubfields(subfields, polishSubfields.bankCode, ""),
+			Address:  joinSubfields(subfields, polishSubfields.address, " "),
+		},
+		Subfields: subfields,
+	}, nil
+}
+
+func ParseGermanInformation(input string) (*TransactionInformation, error) {
+	code, subfields, err := getSubfields(input)
+	if err != nil {
+		return nil, err
+	}
+	info := &TransactionInformation{
+		Info:        input,
+		Code:        code,
+		Description: joinSubfields(subfields, germanSubfields.postingText, ""),
+		Primanota:   joinSubfields(subfields, germanSubfields.primanota, ""),
+		Title:       subfieldLines(subfields, germanSubfields.purpose),
+		Counterparty: Counterparty{
+			Name:     joinSubfields(subfields, germanSubfields.name, ""),
+			BankCode: joinSubfields(subfields, germanSubfields.bankCode, ""),
+			Account:  joinSubfields(subfields, germanSubfields.account, ""),
+		},
+		Sepa:      GetSepaFields(concatSubfields(subfields, germanSubfields.purpose)),
+		Subfields: subfields,
+	}
+	if ibanPattern.MatchString(info.Counterparty.Account) {
+		info.Counterparty.IBAN = info.Counterparty.Account
+	}
+	return info, nil
+}
+
+func GetSepaFields(purpose string) map[string]string {
+	indexes := sepaKeywordPattern.FindAllStringSubmatchIndex(purpose, -1)
+	if len(indexes) == 0 {
+		return nil
+	}
+	fields := make(map[string]string)
+	for i, index := range indexes {
+		end := len(purpose)
+		if i+1 < len(indexes) {
+			end = indexes[i+1][0]
+		}
+		fields[purpose[index[2]:index[3]]] = strings.TrimSpace(purpose[index[1]:end])
+	}
+	return fields
+}
+
+func getSubfields(input string) (string, []Subfield, error) {
+	text := strings.NewReplacer("\r", "", "\n", "").Replace(input)
+	matches := subfieldsPattern.FindStringSubmatch(text)
+	if matches == nil {
+		return "", nil, newParseError(ErrIncorrectFormat, input, "the information does not contain structured subfields")
+	}
+
+	separator := matches[2]
+	var subfields []Subfield
+	for _, part := range strings.Split(text[len(matches[1]):], separator)[1:] {
+		if len(part) < 2 {
+			continue
+		}
+		subfields = append(subfields, Subfield{Key: part[:2], Value: part[2:]})
+	}
+	return matches[1], subfields, nil
+}
+
+func subfieldLines(subfields []Subfield, keys []string) []string {
+	var lines []string
+	for _, subfield := range subfields {
+		if value := strings.TrimSpace(subfield.Value); value != "" && containsKey(keys, subfield.Key) {
+			lines = append(lines, value)
+		}
+	}
+	return lines
+}
+
+func joinSubfields(subfields []Subfield, keys []string, separator string) string {
+	return strings.Join(subfieldLines(subfields, keys), separator)
+}
+
+func concatSubfields(subfields []Subfield, keys []string) string {
+	var builder strings.Builder
+	for _, subfield := range subfields {
+		if containsKey(keys, subfield.Key) {
+			builder.WriteString(subfield.Value)
+		}
+	}
+	return builder.String()
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package mt940_converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolishInformationCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult *TransactionInformation
+		hasError       bool
+	}
+
+	testTable := []testCase{
+		{
+			name: "Polish information with counterparty IBAN",
+			input: "020?00PRZELEW KRAJOWY?10123456?20Faktura VAT?21 FV/1/2023?27ACME SP. Z O.O.\n" +
+				"?28?29UL. DLUGA 1 WARSZAWA?3010500031?38PL61109010140000071219812874",
+			expectedResult: &TransactionInformation{
+				Info: "020?00PRZELEW KRAJOWY?10123456?20Faktura VAT?21 FV/1/2023?27ACME SP. Z O.O.\n" +
+					"?28?29UL. DLUGA 1 WARSZAWA?3010500031?38PL61109010140000071219812874",
+				Code:        "020",
+				Description: "PRZELEW KRAJOWY",
+				Title:       []string{"Faktura VAT", "FV/1/2023"},
+				Counterparty: Counterparty{
+					Name:     "ACME SP. Z O.O.",
+					IBAN:     "PL61109010140000071219812874",
+					BankCode: "10500031",
+					Address:  "UL. DLUGA 1 WARSZAWA",
+				},
+				Subfields: []Subfield{
+					{Key: "00", Value: "PRZELEW KRAJOWY"},
+					{Key: "10", Value: "123456"},
+					{Key: "20", Value: "Faktura VAT"},
+					{Key: "21", Value: " FV/1/2023"},
+					{Key: "27", Value: "ACME SP. Z O.O."},
+					{Key: "28", Value: ""},
+					{Key: "29", Value: "UL. DLUGA 1 WARSZAWA"},
+					{Key: "30", Value: "10500031"},
+					{Key: "38", Value: "PL61109010140000071219812874"},
+				},
+			},
+			hasError: false,
+		},
+		{name: "Unstructured information", input: "824 OPŁATA ZA PRZELEW ELIXIR", expectedResult: nil, hasError: true},
+		{name: "Information without code", input: "?00PRZELEW", expectedResult: nil, hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := ParsePolishInformation(test.input)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestParseGermanInformationCase(t *testing.T) {
+	input := "166?00SEPA-UEBERWEISUNG?109310?20EREF+E2E-REF-0001?21KREF+NOTPROVIDED?22SVWZ+Rechnung 4711 vom 01\n" +
+		"?23.06.2023?24MREF+M-77?25CRED+DE98ZZZ09999999999?30COBADEFFXXX?31DE89370400440532013000\n" +
+		"?32Mustermann GmbH Handel und Ver?33trieb?34000?60ABWA+Max Mustermann"
+
+	actual, err := ParseGermanInformation(input)
+	assert.Nil(t, err)
+	assert.Equal(t, "166", actual.Code)
+	assert.Equal(t, "SEPA-UEBERWEISUNG", actual.Description)
+	assert.Equal(t, "9310", actual.Primanota)
+	assert.Equal(t, []string{
+		"EREF+E2E-REF-0001",
+		"KREF+NOTPROVIDED",
+		"SVWZ+Rechnung 4711 vom 01",
+		".06.2023",
+		"MREF+M-77",
+		"CRED+DE98ZZZ09999999999",
+		"ABWA+Max Mustermann",
+	}, actual.Title)
+	assert.Equal(t, Counterparty{
+		Name:     "Mustermann GmbH Handel und Vertrieb",
+		IBAN:     "DE89370400440532013000",
+		Account:  "DE89370400440532013000",
+		BankCode: "COBADEFFXXX",
+	}, actual.Counterparty)
+	assert.Equal(t, map[string]string{
+		"EREF": "E2E-REF-0001",
+		"KREF": "NOTPROVIDED",
+		"SVWZ": "Rechnung 4711 vom 01.06.2023",
+		"MREF": "M-77",
+		"CRED": "DE98ZZZ09999999999",
+		"ABWA": "Max Mustermann",
+	}, actual.Sepa)
+	assert.Len(t, actual.Subfields, 14)
+
+	_, err = ParseGermanInformation("Verwendungszweck ohne Struktur")
+	assert.NotNil(t, err)
+}
+
+func TestGetTransactionInfoSubfieldsCase(t *testing.T) {
+	type testCase struct {
+		name                string
+		input               string
+		expectedDescription string
+		expectedName        string
+		expectedSepa        map[string]string
+	}
+
+	testTable := []testCase{
+		{
+			name:                "German information",
+			input:               "2306020602CN100,NTRFNONREF\n:86:166?00SEPA-GUTSCHRIFT?20EREF+E2E-1?21SVWZ+Miete?32Max Mustermann",
+			expectedDescription: "SEPA-GUTSCHRIFT",
+			expectedName:        "Max Mustermann",
+			expectedSepa:        map[string]string{"EREF": "E2E-1", "SVWZ": "Miete"},
+		},
+		{
+			name:                "Polish information",
+			input:               "2306020602CN100,NTRFNONREF\n:86:020?00PRZELEW KRAJOWY?20Faktura VAT?27ACME SP. Z O.O.",
+			expectedDescription: "PRZELEW KRAJOWY",
+			expectedName:        "ACME SP. Z O.O.",
+		},
+	}
+
+	for _, test := range testTable {
+		actual := GetTransactionInfo(test.input)
+		assert.Equal(t, test.expectedDescription, actual.Description, test.name)
+		assert.Equal(t, test.expectedName, actual.Counterparty.Name, test.name)
+		assert.Equal(t, test.expectedSepa, actual.Sepa, test.name)
+	}
+}
+
+func TestGetSepaFieldsCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult map[string]string
+	}
+
+	testTable := []testCase{
+		{name: "Purpose with keywords", input: "EREF+123 SVWZ+Miete Juni", expectedResult: map[string]string{"EREF": "123", "SVWZ": "Miete Juni"}},
+		{name: "Purpose without keywords", input: "Miete Juni", expectedResult: nil},
+		{name: "Purpose is empty", input: "", expectedResult: nil},
+	}
+
+	for _, test := range testTable {
+		assert.Equal(t, test.expectedResult, GetSepaFields(test.input), test.name)
+	}
+}
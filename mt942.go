@@ -0,0 +1,307 @@
+package mt940_converter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	floorLimit         = ":34F:"
+	dateTimeIndication = ":13D:"
+	debitEntries       = ":90D:"
+	creditEntries      = ":90C:"
+)
+
+type FloorLimit struct {
+	Currency        string
+	TransactionType TransactionType
+	Amount          MyDecimal
+}
+
+type DateTimeIndication struct {
+	Date      LongDate
+	Hour      int64
+	Minute    int64
+	UTCOffset string
+}
+
+type EntrySummary struct {
+	TransactionType TransactionType
+	Count           int64
+	Currency        string
+	Amount          MyDecimal
+}
+
+type MT942 struct {
+	ReferenceNumber       ReferenceNumber
+	RelatedReference      *RelatedReference
+	AccountIdentification AccountIdentification
+	StatementNumber       StatementNumber
+	DebitFloorLimit       FloorLimit
+	CreditFloorLimit      FloorLimit
+	DateTimeIndication    DateTimeIndication
+	Transactions          []Transaction
+	DebitEntries          *EntrySummary
+	CreditEntries         *EntrySummary
+	Information           string
+	Envelope              *FinEnvelope
+	Dialect               string
+	Encoding              Encoding
+	Errors                []*ParseError
+	Warnings              []*ParseError
+	Quarantined           []QuarantinedTransaction
+}
+
+var mandatoryMT942Tags = []string{
+	referenceNumber,
+	accountIdentification,
+	statementNumber,
+	floorLimit,
+	dateTimeIndication,
+}
+
+var (
+	floorLimitPattern         = regexp.MustCompile(`^([A-Z]{3})([DC])?([0-9,]{1,15})$`)
+	dateTimeIndicationPattern = regexp.MustCompile(`^([0-9]{6})([0-9]{2})([0-9]{2})([+-][0-9]{4})$`)
+	entrySummaryPattern       = regexp.MustCompile(`^([0-9]{1,5})([A-Z]{3})([0-9,]{1,15})$`)
+)
+
+func ParseMT942(input string, opts ...Option) (*MT942, error) {
+	o := newOptions(opts)
+	input, encoding, err := decodeInput(input, o.encoding)
+	if err != nil {
+		return nil, err
+	}
+	text, envelope, err := unwrapMessage(input)
+	if err != nil {
+		return nil, err
+	}
+	fields := tokenize(text)
+	dialect := o.getDialect(fields, envelope)
+	report, err := newMT942(fields, dialect, o)
+	if err != nil {
+		return nil, withLineOffset(err, lineOffset(input, text))
+	}
+	for _, parseError := range append(report.Errors, report.Warnings...) {
+		withLineOffset(parseError, lineOffset(input, text))
+	}
+	report.Envelope = envelope
+	report.Dialect = dialect.Name()
+	report.Encoding = encoding
+	return report, nil
+}
+
+func newMT942(fields []field, dialect Dialect, o options) (*MT942, error) {
+	var report MT942
+	collected := newDiagnostics(o)
+	if err := collected.validateFields(fields); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+
+		if f.Tag == transaction {
+			index := len(report.Transactions) + len(report.Quarantined) + 1
+			result, next, err := nextTransaction(fields, i, index, dialect)
+			if err != nil {
+				if err := collected.add(err); err != nil {
+					return nil, err
+				}
+				report.Quarantined = append(report.Quarantined, newQuarantinedTransaction(fields[i:next+1], index, err))
+			} else {
+				report.Transactions = append(report.Transactions, *result)
+			}
+			i = next
+		} else if err := report.setField(f, dialect, seen[f.Tag]); err != nil {
+			if err := collected.add(withPosition(err, f)); err != nil {
+				return nil, err
+			}
+		}
+		seen[f.Tag] = true
+	}
+
+	if err := missingTags(seen, mandatoryMT942Tags); err != nil {
+		if err := collected.add(err); err != nil {
+			return nil, err
+		}
+	}
+	report.Errors = collected.errors
+	report.Warnings = collected.warnings
+	return &report, nil
+}
+
+func (r *MT942) setField(f field, dialect Dialect, repeated bool) error {
+	switch f.Tag {
+	case referenceNumber:
+		result, err := GetReferenceNumber(f.text())
+		if err != nil {
+			return err
+		}
+		r.ReferenceNumber = *result
+	case relatedReference:
+		result, err := GetRelatedReference(f.text())
+		if err != nil {
+			return err
+		}
+		r.RelatedReference = result
+	case accountIdentification:
+		result, err := dialect.ParseAccount(f.text())
+		if err != nil {
+			return err
+		}
+		r.AccountIdentification = *result
+	case statementNumber:
+		result, err := GetStatementNumber(f.text())
+		if err != nil {
+			return err
+		}
+		r.StatementNumber = *result
+	case floorLimit:
+		result, err := GetFloorLimit(f.text())
+		if err != nil {
+			return err
+		}
+		switch {
+		case result.TransactionType == DEBIT:
+			r.DebitFloorLimit = *result
+		case result.TransactionType == CREDIT || repeated:
+			r.CreditFloorLimit = *result
+		default:
+			r.DebitFloorLimit = *result
+			r.CreditFloorLimit = *result
+		}
+	case dateTimeIndication:
+		result, err := GetDateTimeIndication(f.text())
+		if err != nil {
+			return err
+		}
+		r.DateTimeIndication = *result
+	case debitEntries:
+		result, err := GetEntrySummary(f.text(), DEBIT)
+		if err != nil {
+			return err
+		}
+		r.DebitEntries = result
+	case creditEntries:
+		result, err := GetEntrySummary(f.text(), CREDIT)
+		if err != nil {
+			return err
+		}
+		r.CreditEntries = result
+	case transactionDescription:
+		if r.Information != "" {
+			r.Information += "\n"
+		}
+		r.Information += f.Value
+	}
+	return nil
+}
+
+func GetFloorLimit(input string) (*FloorLimit, error) {
+	if !strings.HasPrefix(input, floorLimit) {
+		return nil, newTagNotFoundError(input, "floor limit", floorLimit)
+	}
+	result := tagValue(input, floorLimit)
+	matches := floorLimitPattern.FindStringSubmatch(result)
+	if matches == nil {
+		return nil, newFieldError(ErrIncorrectFormat, floorLimit, result, 0, "the floor limit format is incorrect: %s", result)
+	}
+	amount, err := GetDecimal(matches[3])
+	if err != nil {
+		return nil, wrapFieldError(err, floorLimit, result, len(matches[1])+len(matches[2]), "cannot parse amount")
+	}
+	return &FloorLimit{
+		Currency:        matches[1],
+		TransactionType: GetTransactionType(matches[2]),
+		Amount:          amount,
+	}, nil
+}
+
+func GetDateTimeIndication(input string) (*DateTimeIndication, error) {
+	if !strings.HasPrefix(input, dateTimeIndication) {
+		return nil, newTagNotFoundError(input, "date time indication", dateTimeIndication)
+	}
+	result := tagValue(input, dateTimeIndication)
+	matches := dateTimeIndicationPattern.FindStringSubmatch(result)
+	if matches == nil {
+		return nil, newFieldError(ErrIncorrectFormat, dateTimeIndication, result, 0, "the date time indication format is incorrect: %s", result)
+	}
+	date, err := GetLongDate(matches[1])
+	if err != nil {
+		return nil, wrapFieldError(err, dateTimeIndication, result, 0, "cannot parse date")
+	}
+	hour, err := strconv.ParseInt(matches[2], 10, 8)
+	if err != nil || hour > 23 {
+		return nil, newFieldError(ErrIncorrectDate, dateTimeIndication, result, 6, "the hour is incorrect: %s", matches[2])
+	}
+	minute, err := strconv.ParseInt(matches[3], 10, 8)
+	if err != nil || minute > 59 {
+		return nil, newFieldError(ErrIncorrectDate, dateTimeIndication, result, 8, "the minute is incorrect: %s", matches[3])
+	}
+	offsetHours, err := strconv.ParseInt(matches[4][1:3], 10, 8)
+	if err != nil || offsetHours > 14 {
+		return nil, newFieldError(ErrIncorrectDate, dateTimeIndication, result, 10, "the UTC offset is incorrect: %s", matches[4])
+	}
+	offsetMinutes, err := strconv.ParseInt(matches[4][3:5], 10, 8)
+	if err != nil || offsetMinutes > 59 {
+		return nil, newFieldError(ErrIncorrectDate, dateTimeIndication, result, 10, "the UTC offset is incorrect: %s", matches[4])
+	}
+	return &DateTimeIndication{
+		Date:      *date,
+		Hour:      hour,
+		Minute:    minute,
+		UTCOffset: matches[4],
+	}, nil
+}
+
+func (d DateTimeIndication) Time() time.Time {
+	location := time.UTC
+	if len(d.UTCOffset) == 5 {
+		hours, _ := strconv.Atoi(d.UTCOffset[1:3])
+		minutes, _ := strconv.Atoi(d.UTCOffset[3:5])
+		offset := hours*3600 + minutes*60
+		if d.UTCOffset[0] == '-' {
+			offset = -offset
+		}
+		location = time.FixedZone(d.UTCOffset, offset)
+	}
+	date := d.Date.Time()
+	return time.Date(date.Year(), date.Month(), date.Day(), int(d.Hour), int(d.Minute), 0, 0, location)
+}
+
+func GetEntrySummary(input string, transactionType TransactionType) (*EntrySummary, error) {
+	var tag string
+	if transactionType == DEBIT {
+		tag = debitEntries
+	}
+	if transactionType == CREDIT {
+		tag = creditEntries
+	}
+	if tag == "" {
+		return nil, newParseError(ErrIncorrectType, input, "incorrect transaction type: %v", transactionType)
+	}
+
+	if !strings.HasPrefix(input, tag) {
+		return nil, newTagNotFoundError(input, "number and sum of entries", tag)
+	}
+	result := tagValue(input, tag)
+	matches := entrySummaryPattern.FindStringSubmatch(result)
+	if matches == nil {
+		return nil, newFieldError(ErrIncorrectFormat, tag, result, 0, "the number and sum of entries format is incorrect: %s", result)
+	}
+	count, _ := strconv.ParseInt(matches[1], 10, 32)
+	amount, err := GetDecimal(matches[3])
+	if err != nil {
+		return nil, wrapFieldError(err, tag, result, len(matches[1])+len(matches[2]), "cannot parse amount")
+	}
+	return &EntrySummary{
+		TransactionType: transactionType,
+		Count:           count,
+		Currency:        matches[2],
+		Amount:          amount,
+	}, nil
+}
@@ -0,0 +1,173 @@
+package mt940_converter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mt942Input = ":20:INTERIM1\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28C:00012/001\r\n" +
+	":34F:EURD100,00\r\n" +
+	":34F:EURC250,00\r\n" +
+	":13D:2306031215+0200\r\n" +
+	":61:2306030603DN449,77NTRFSP300//BR05012139000001\r\n" +
+	":86:944 Przelew krajowy\r\n" +
+	":90D:1EUR449,77\r\n" +
+	":90C:0EUR0,\r\n" +
+	"-\r\n"
+
+func TestParseMT942Case(t *testing.T) {
+	debitLimit, _ := GetDecimal("100,00")
+	creditLimit, _ := GetDecimal("250,00")
+	amount, _ := GetDecimal("449,77")
+	zero, _ := GetDecimal("0,")
+
+	actual, err := ParseMT942(mt942Input)
+	assert.Nil(t, err)
+	assert.Equal(t, ReferenceNumber{Value: "INTERIM1"}, actual.ReferenceNumber)
+	assert.Equal(t, StatementNumber{Value: "00012/001"}, actual.StatementNumber)
+	assert.Equal(t, FloorLimit{Currency: "EUR", TransactionType: DEBIT, Amount: debitLimit}, actual.DebitFloorLimit)
+	assert.Equal(t, FloorLimit{Currency: "EUR", TransactionType: CREDIT, Amount: creditLimit}, actual.CreditFloorLimit)
+	assert.Equal(t, DateTimeIndication{
+		Date:      LongDate{Year: 23, Month: 6, Day: 3},
+		Hour:      12,
+		Minute:    15,
+		UTCOffset: "+0200",
+	}, actual.DateTimeIndication)
+	assert.Len(t, actual.Transactions, 1)
+	assert.Equal(t, amount, actual.Transactions[0].Statement.Amount)
+	assert.Equal(t, &EntrySummary{TransactionType: DEBIT, Count: 1, Currency: "EUR", Amount: amount}, actual.DebitEntries)
+	assert.Equal(t, &EntrySummary{TransactionType: CREDIT, Count: 0, Currency: "EUR", Amount: zero}, actual.CreditEntries)
+}
+
+func TestParseMT942SingleFloorLimitCase(t *testing.T) {
+	limit, _ := GetDecimal("100,00")
+
+	actual, err := ParseMT942(":20:INTERIM1\r\n:25:NL17RABO6064103256EUR\r\n:28C:1\r\n:34F:EUR100,00\r\n:13D:2306031215+0200\r\n-\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, FloorLimit{Currency: "EUR", Amount: limit}, actual.DebitFloorLimit)
+	assert.Equal(t, actual.DebitFloorLimit, actual.CreditFloorLimit)
+	assert.Nil(t, actual.DebitEntries)
+}
+
+func TestParseMT942FloorLimitMarkCase(t *testing.T) {
+	debitLimit, _ := GetDecimal("100,00")
+	creditLimit, _ := GetDecimal("250,00")
+	debit := FloorLimit{Currency: "EUR", TransactionType: DEBIT, Amount: debitLimit}
+	credit := FloorLimit{Currency: "EUR", TransactionType: CREDIT, Amount: creditLimit}
+
+	type testCase struct {
+		name           string
+		floorLimits    string
+		expectedDebit  FloorLimit
+		expectedCredit FloorLimit
+	}
+
+	testTable := []testCase{
+		{name: "Debit limit first", floorLimits: ":34F:EURD100,00\r\n:34F:EURC250,00\r\n", expectedDebit: debit, expectedCredit: credit},
+		{name: "Credit limit first", floorLimits: ":34F:EURC250,00\r\n:34F:EURD100,00\r\n", expectedDebit: debit, expectedCredit: credit},
+		{name: "Only a credit limit", floorLimits: ":34F:EURC250,00\r\n", expectedCredit: credit},
+		{name: "Only a debit limit", floorLimits: ":34F:EURD100,00\r\n", expectedDebit: debit},
+	}
+
+	for _, test := range testTable {
+		actual, err := ParseMT942(":20:INTERIM1\r\n:25:NL17RABO6064103256EUR\r\n:28C:1\r\n" + test.floorLimits + ":13D:2306031215+0200\r\n-\r\n")
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.expectedDebit, actual.DebitFloorLimit, test.name)
+		assert.Equal(t, test.expectedCredit, actual.CreditFloorLimit, test.name)
+	}
+}
+
+func TestGetFloorLimitCase(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		hasError bool
+	}
+
+	testTable := []testCase{
+		{name: "Floor limit is correct", input: ":34F:EUR100,00\r\n", hasError: false},
+		{name: "Floor limit with mark is correct", input: ":34F:EURD100,00\r\n", hasError: false},
+		{name: "Floor limit without currency", input: ":34F:100,00\r\n", hasError: true},
+		{name: "Floor limit tag not found", input: ":34:EUR100,00\r\n", hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := GetFloorLimit(test.input)
+
+		if test.hasError {
+			assert.Nil(t, actual, test.name)
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.NotNil(t, actual, test.name)
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestGetDateTimeIndicationCase(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		hasError bool
+	}
+
+	testTable := []testCase{
+		{name: "Date time indication is correct", input: ":13D:2306031215+0200\r\n", hasError: false},
+		{name: "Date time indication without offset", input: ":13D:2306031215\r\n", hasError: true},
+		{name: "Date time indication tag not found", input: ":13:2306031215+0200\r\n", hasError: true},
+		{name: "Date time indication at the end of the day", input: ":13D:2306032359+1400\r\n", hasError: false},
+		{name: "Date time indication with incorrect hour", input: ":13D:2306032415+0200\r\n", hasError: true},
+		{name: "Date time indication with incorrect minute", input: ":13D:2306031260+0200\r\n", hasError: true},
+		{name: "Date time indication with incorrect hour and minute", input: ":13D:2306032575+0200\r\n", hasError: true},
+		{name: "Date time indication with incorrect offset hours", input: ":13D:2306031215+1500\r\n", hasError: true},
+		{name: "Date time indication with incorrect offset minutes", input: ":13D:2306031215-0260\r\n", hasError: true},
+		{name: "Date time indication with incorrect offset", input: ":13D:2306031215+9999\r\n", hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := GetDateTimeIndication(test.input)
+
+		if test.hasError {
+			assert.Nil(t, actual, test.name)
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.NotNil(t, actual, test.name)
+			assert.Nil(t, err, test.name)
+		}
+	}
+
+	_, err := GetDateTimeIndication(":13D:2306032575+9999\r\n")
+	assert.True(t, errors.Is(err, ErrIncorrectDate))
+}
+
+func TestGetEntrySummaryCase(t *testing.T) {
+	type testCase struct {
+		name            string
+		input           string
+		transactionType TransactionType
+		hasError        bool
+	}
+
+	testTable := []testCase{
+		{name: "Debit entries are correct", input: ":90D:12EUR100,00\r\n", transactionType: DEBIT, hasError: false},
+		{name: "Credit entries are correct", input: ":90C:3EUR100,00\r\n", transactionType: CREDIT, hasError: false},
+		{name: "Entries count is too long", input: ":90D:123456EUR100,00\r\n", transactionType: DEBIT, hasError: true},
+		{name: "Entries tag does not match type", input: ":90D:12EUR100,00\r\n", transactionType: CREDIT, hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := GetEntrySummary(test.input, test.transactionType)
+
+		if test.hasError {
+			assert.Nil(t, actual, test.name)
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.NotNil(t, actual, test.name)
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package mt940_converter
+
+import "time"
+
+type Option func(*options)
+
+type Mode string
+
+const (
+	STRICT_MODE  Mode = "strict"
+	LENIENT_MODE      = "lenient"
+)
+
+type options struct {
+	dialect     Dialect
+	messageType MessageType
+	mode        Mode
+	validation  ValidationLevel
+	encoding    Encoding
+	lineEnding  string
+	createdAt   time.Time
+}
+
+func newOptions(opts []Option) options {
+	result := options{mode: STRICT_MODE, validation: VALIDATION_OFF}
+	for _, opt := range opts {
+		opt(&result)
+	}
+	return result
+}
+
+func WithDialect(dialect Dialect) Option {
+	return func(o *options) {
+		o.dialect = dialect
+	}
+}
+
+func WithMessageType(messageType MessageType) Option {
+	return func(o *options) {
+		o.messageType = messageType
+	}
+}
+
+func WithMode(mode Mode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+func WithValidation(level ValidationLevel) Option {
+	return func(o *options) {
+		o.validation = level
+	}
+}
+
+func WithEncoding(encoding Encoding) Option {
+	return func(o *options) {
+		o.encoding = encoding
+	}
+}
+
+func WithLineEnding(lineEnding string) Option {
+	return func(o *options) {
+		o.lineEnding = lineEnding
+	}
+}
+
+func WithCreationTime(createdAt time.Time) Option {
+	return func(o *options) {
+		o.createdAt = createdAt
+	}
+}
+
+func (o options) getLineEnding() string {
+	if o.lineEnding == "" {
+		return crlf
+	}
+	return o.lineEnding
+}
+
+func (o options) getDialect(fields []field, envelope *FinEnvelope) Dialect {
+	if o.dialect != nil {
+		return o.dialect
+	}
+	return DetectDialect(getDetectionHints(fields, envelope))
+}
+
+func (o options) isLenient() bool {
+	return o.mode == LENIENT_MODE
+}
+
+func (o options) getCreationTime() time.Time {
+	if o.createdAt.IsZero() {
+		return time.Now()
+	}
+	return o.createdAt
+}
@@ -0,0 +1,133 @@
+package mt940_converter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+func (s Statement) IsFirstPage() bool {
+	return s.OpeningBalance.BalanceType != INTERMEDIATE_OPENING
+}
+
+func (s Statement) IsLastPage() bool {
+	return s.ClosingBalance.BalanceType != INTERMEDIATE_CLOSING
+}
+
+func StitchStatements(statements []Statement) ([]Statement, error) {
+	var keys []string
+	groups := make(map[string][]Statement)
+	for _, stmt := range statements {
+		key := stmt.ReferenceNumber.Value + "|" +
+			stmt.AccountIdentification.CountryIso + stmt.AccountIdentification.Iban + stmt.AccountIdentification.Currency + "|" +
+			stmt.StatementNumber.Number()
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], stmt)
+	}
+
+	var result []Statement
+	for _, key := range keys {
+		stitched, err := stitchPages(groups[key])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *stitched)
+	}
+	return result, nil
+}
+
+func stitchPages(pages []Statement) (*Statement, error) {
+	if len(pages) == 1 && pages[0].StatementNumber.Sequence() == "" {
+		return &pages[0], nil
+	}
+	sort.SliceStable(pages, func(i, j int) bool {
+		return pageSequence(pages[i]) < pageSequence(pages[j])
+	})
+	if err := checkPageSequences(pages); err != nil {
+		return nil, err
+	}
+	if len(pages) == 1 {
+		return &pages[0], nil
+	}
+
+	stmt := pages[0]
+	stmt.Transactions = nil
+	stmt.Errors = nil
+	stmt.Warnings = nil
+	stmt.Quarantined = nil
+	offset := 0
+	for i, page := range pages {
+		if i > 0 {
+			previous := pages[i-1]
+			if !decimal.Decimal(previous.ClosingBalance.Amount).Equal(decimal.Decimal(page.OpeningBalance.Amount)) ||
+				previous.ClosingBalance.TransactionType != page.OpeningBalance.TransactionType {
+				return nil, fmt.Errorf("the balances of statement %s pages %s and %s do not match", stmt.StatementNumber.Number(), previous.StatementNumber.Sequence(), page.StatementNumber.Sequence())
+			}
+			if page.Information != "" {
+				if stmt.Information != "" {
+					stmt.Information += "\n"
+				}
+				stmt.Information += page.Information
+			}
+		}
+		for _, transaction := range page.Transactions {
+			transaction.Index += offset
+			stmt.Transactions = append(stmt.Transactions, transaction)
+		}
+		for _, quarantined := range page.Quarantined {
+			quarantined.Index += offset
+			quarantined.Errors = offsetErrors(quarantined.Errors, offset)
+			stmt.Quarantined = append(stmt.Quarantined, quarantined)
+		}
+		stmt.Errors = append(stmt.Errors, offsetErrors(page.Errors, offset)...)
+		stmt.Warnings = append(stmt.Warnings, offsetErrors(page.Warnings, offset)...)
+		offset += len(page.Transactions) + len(page.Quarantined)
+	}
+
+	last := pages[len(pages)-1]
+	stmt.StatementNumber = StatementNumber{Value: stmt.StatementNumber.Number()}
+	stmt.ClosingBalance = last.ClosingBalance
+	stmt.AvailableBalance = last.AvailableBalance
+	stmt.ForwardBalances = last.ForwardBalances
+	return &stmt, nil
+}
+
+func checkPageSequences(pages []Statement) error {
+	for i, page := range pages {
+		sequence := pageSequence(page)
+		if sequence == i+1 {
+			continue
+		}
+		parseError := newParseError(ErrIncorrectFormat, page.StatementNumber.Value, "the statement %s is missing page %v", page.StatementNumber.Number(), i+1)
+		if i > 0 && sequence == pageSequence(pages[i-1]) {
+			parseError = newParseError(ErrIncorrectFormat, page.StatementNumber.Value, "the statement %s has duplicated page %s", page.StatementNumber.Number(), page.StatementNumber.Sequence())
+		}
+		parseError.Tag = statementNumber
+		return parseError
+	}
+	return nil
+}
+
+func offsetErrors(errors []*ParseError, offset int) []*ParseError {
+	var result []*ParseError
+	for _, parseError := range errors {
+		copied := *parseError
+		if copied.Transaction > 0 {
+			copied.Transaction += offset
+		}
+		result = append(result, &copied)
+	}
+	return result
+}
+
+func pageSequence(s Statement) int {
+	sequence, err := strconv.Atoi(s.StatementNumber.Sequence())
+	if err != nil {
+		return 0
+	}
+	return sequence
+}
@@ -0,0 +1,131 @@
+package mt940_converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const firstPageInput = ":20:STARTUMS\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28C:00005/001\r\n" +
+	":60F:C230601EUR1000,00\r\n" +
+	":61:2306020602DN2,50NCHGNONREF//BR07282102000059\r\n" +
+	":86:824 OPŁATA ZA PRZELEW ELIXIR\r\n" +
+	":62M:C230602EUR997,50\r\n" +
+	"-\r\n"
+
+const secondPageInput = ":20:STARTUMS\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28C:00005/002\r\n" +
+	":60M:C230602EUR997,50\r\n" +
+	":61:2306030603CN449,77NTRFSP300//BR05012139000001\r\n" +
+	":86:944 Przelew krajowy\r\n" +
+	":62F:C230603EUR1447,27\r\n" +
+	"-\r\n"
+
+func TestIntermediateBalanceCase(t *testing.T) {
+	first, err := ParseStatement(firstPageInput)
+	assert.Nil(t, err)
+	assert.True(t, first.IsFirstPage())
+	assert.False(t, first.IsLastPage())
+	assert.Equal(t, BalanceType(INTERMEDIATE_CLOSING), first.ClosingBalance.BalanceType)
+
+	second, err := ParseStatement(secondPageInput)
+	assert.Nil(t, err)
+	assert.False(t, second.IsFirstPage())
+	assert.True(t, second.IsLastPage())
+	assert.Equal(t, BalanceType(INTERMEDIATE_OPENING), second.OpeningBalance.BalanceType)
+
+	balance, err := GetBalance(":60M:C230602EUR997,50\r\n", INTERMEDIATE_OPENING)
+	assert.Nil(t, err)
+	assert.Equal(t, second.OpeningBalance, *balance)
+}
+
+func TestStitchStatementsCase(t *testing.T) {
+	first, _ := ParseStatement(firstPageInput)
+	second, _ := ParseStatement(secondPageInput)
+	other, _ := ParseStatement(secondStatementInput)
+
+	actual, err := StitchStatements([]Statement{*second, *other, *first})
+	assert.Nil(t, err)
+	assert.Len(t, actual, 2)
+
+	stitched := actual[0]
+	assert.Equal(t, StatementNumber{Value: "00005"}, stitched.StatementNumber)
+	assert.Equal(t, first.OpeningBalance, stitched.OpeningBalance)
+	assert.Equal(t, second.ClosingBalance, stitched.ClosingBalance)
+	assert.Len(t, stitched.Transactions, 2)
+	assert.Equal(t, 1, stitched.Transactions[0].Index)
+	assert.Equal(t, first.Transactions[0].Statement, stitched.Transactions[0].Statement)
+	assert.Equal(t, 2, stitched.Transactions[1].Index)
+	assert.Equal(t, second.Transactions[0].Statement, stitched.Transactions[1].Statement)
+	assert.Equal(t, *other, actual[1])
+}
+
+func TestStitchStatementsErrorCase(t *testing.T) {
+	first, _ := ParseStatement(firstPageInput)
+	second, _ := ParseStatement(secondPageInput)
+	second.OpeningBalance.TransactionType = DEBIT
+
+	_, err := StitchStatements([]Statement{*first, *second})
+	assert.NotNil(t, err)
+
+	_, err = StitchStatements([]Statement{*first, *first})
+	assert.NotNil(t, err)
+}
+
+func TestStitchStatementsSequenceCase(t *testing.T) {
+	first, _ := ParseStatement(firstPageInput)
+	second, _ := ParseStatement(secondPageInput)
+	fourth, _ := ParseStatement(strings.Replace(secondPageInput, "00005/002", "00005/004", 1))
+
+	type testCase struct {
+		name  string
+		pages []Statement
+	}
+
+	testTable := []testCase{
+		{name: "Gap between pages", pages: []Statement{*first, *second, *fourth}},
+		{name: "Duplicated first page", pages: []Statement{*first, *first}},
+		{name: "Missing first page", pages: []Statement{*second}},
+		{name: "Pages without the first one", pages: []Statement{*second, *fourth}},
+	}
+
+	for _, test := range testTable {
+		actual, err := StitchStatements(test.pages)
+		assert.Nil(t, actual, test.name)
+		assert.ErrorIs(t, err, ErrIncorrectFormat, test.name)
+
+		var parseError *ParseError
+		assert.ErrorAs(t, err, &parseError, test.name)
+		assert.Equal(t, statementNumber, parseError.Tag, test.name)
+	}
+}
+
+func TestStitchStatementsDiagnosticsCase(t *testing.T) {
+	secondPage := strings.Replace(secondPageInput, ":61:", ":61:230603XXXXCN1,00NTRFBROKEN\r\n:61:", 1)
+
+	first, err := ParseStatement(firstPageInput, WithMode(LENIENT_MODE))
+	assert.Nil(t, err)
+	second, err := ParseStatement(secondPage, WithMode(LENIENT_MODE))
+	assert.Nil(t, err)
+	assert.Len(t, second.Quarantined, 1)
+	assert.Len(t, second.Errors, 1)
+
+	actual, err := StitchStatements([]Statement{*second, *first})
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+
+	stitched := actual[0]
+	assert.Len(t, stitched.Transactions, 2)
+	assert.Equal(t, 1, stitched.Transactions[0].Index)
+	assert.Equal(t, 3, stitched.Transactions[1].Index)
+	assert.Len(t, stitched.Quarantined, 1)
+	assert.Equal(t, 2, stitched.Quarantined[0].Index)
+	assert.Equal(t, 2, stitched.Quarantined[0].Errors[0].Transaction)
+	assert.Len(t, stitched.Errors, 1)
+	assert.Equal(t, 2, stitched.Errors[0].Transaction)
+	assert.Equal(t, 1, second.Errors[0].Transaction)
+}
@@ -0,0 +1,367 @@
+package mt940_converter
+
+import (
+	"sort"
+	"strings"
+)
+
+type MessageType string
+
+const (
+	MESSAGE_940 MessageType = "940"
+	MESSAGE_941             = "941"
+	MESSAGE_942             = "942"
+	MESSAGE_950             = "950"
+)
+
+type Statement struct {
+	MessageType           MessageType
+	ReferenceNumber       ReferenceNumber
+	RelatedReference      *RelatedReference
+	AccountIdentification AccountIdentification
+	StatementNumber       StatementNumber
+	OpeningBalance        Balance
+	ClosingBalance        Balance
+	AvailableBalance      *Balance
+	ForwardBalances       []Balance
+	DateTimeIndication    *DateTimeIndication
+	DebitEntries          *EntrySummary
+	CreditEntries         *EntrySummary
+	Transactions          []Transaction
+	Information           string
+	Envelope              *FinEnvelope
+	Dialect               string
+	Encoding              Encoding
+	Errors                []*ParseError
+	Warnings              []*ParseError
+	Quarantined           []QuarantinedTransaction
+}
+
+type QuarantinedTransaction struct {
+	Index       int
+	Statement   string
+	Information string
+	Errors      []*ParseError
+}
+
+var mandatoryStatementTags = map[MessageType][]string{
+	MESSAGE_940: {referenceNumber, accountIdentification, statementNumber, openingBalance, closingBalance},
+	MESSAGE_941: {referenceNumber, accountIdentification, balanceStatementNumber, closingBalance},
+	MESSAGE_950: {referenceNumber, accountIdentification, statementNumber, openingBalance, closingBalance},
+}
+
+var intermediateBalanceTags = map[string]string{
+	intermediateOpening: openingBalance,
+	intermediateClosing: closingBalance,
+}
+
+func ParseStatement(input string, opts ...Option) (*Statement, error) {
+	return parseStatement(input, "", newOptions(opts))
+}
+
+func ParseMT950(input string, opts ...Option) (*Statement, error) {
+	return parseStatement(input, MESSAGE_950, newOptions(opts))
+}
+
+func ParseMT941(input string, opts ...Option) (*Statement, error) {
+	return parseStatement(input, MESSAGE_941, newOptions(opts))
+}
+
+func parseStatement(input string, messageType MessageType, o options) (*Statement, error) {
+	input, encoding, err := decodeInput(input, o.encoding)
+	if err != nil {
+		return nil, err
+	}
+	text, envelope, err := unwrapMessage(input)
+	if err != nil {
+		return nil, err
+	}
+	fields := tokenize(text)
+	if messageType == "" {
+		messageType = getMessageType(fields, envelope, o)
+	}
+	if _, ok := mandatoryStatementTags[messageType]; !ok {
+		return nil, newParseError(ErrUnsupportedMessage, input, "unsupported message type: %v", messageType)
+	}
+	dialect := o.getDialect(fields, envelope)
+	stmt, err := newStatement(fields, messageType, dialect, o)
+	if err != nil {
+		return nil, withLineOffset(err, lineOffset(input, text))
+	}
+	for _, parseError := range append(stmt.Errors, stmt.Warnings...) {
+		withLineOffset(parseError, lineOffset(input, text))
+	}
+	stmt.Envelope = envelope
+	stmt.Dialect = dialect.Name()
+	stmt.Encoding = encoding
+	return stmt, nil
+}
+
+func getMessageType(fields []field, envelope *FinEnvelope, o options) MessageType {
+	if o.messageType != "" {
+		return o.messageType
+	}
+	if envelope != nil && envelope.MessageType() != "" {
+		return MessageType(envelope.MessageType())
+	}
+	return inferMessageType(fields)
+}
+
+func detectMessageType(input string, o options) MessageType {
+	input, _, err := decodeInput(input, o.encoding)
+	if err != nil {
+		return ""
+	}
+	text, envelope, err := unwrapMessage(input)
+	if err != nil {
+		return ""
+	}
+	return getMessageType(tokenize(text), envelope, o)
+}
+
+func inferMessageType(fields []field) MessageType {
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		seen[f.Tag] = true
+	}
+	switch {
+	case seen[floorLimit] || seen[dateTimeIndication] && !seen[balanceStatementNumber]:
+		return MESSAGE_942
+	case seen[balanceStatementNumber]:
+		return MESSAGE_941
+	default:
+		return MESSAGE_940
+	}
+}
+
+func unwrapMessage(input string) (string, *FinEnvelope, error) {
+	if !IsFinMessage(input) {
+		return input, nil, nil
+	}
+	message, err := ParseFinMessage(input)
+	if err != nil {
+		return "", nil, err
+	}
+	return message.Text, message.Envelope, nil
+}
+
+func lineOffset(input string, text string) int {
+	index := strings.Index(input, text)
+	if index <= 0 {
+		return 0
+	}
+	return strings.Count(normalizeLineEndings(input[:index]), "\n")
+}
+
+func nextTransaction(fields []field, i int, index int, dialect Dialect) (*Transaction, int, error) {
+	start := i
+	var information []string
+	for i+1 < len(fields) && fields[i+1].Tag == transactionDescription {
+		i++
+		information = append(information, fields[i].Value)
+	}
+	var info TransactionInformation
+	if len(information) > 0 {
+		info = dialect.ParseInformation(strings.Join(information, "\n"))
+	}
+	result, err := GetStatement(fields[start].Value)
+	if err != nil {
+		parseError := wrapFieldError(err, transaction, fields[start].Value, 0, "cannot parse transaction %v", index)
+		parseError.Transaction = index
+		return nil, i, withPosition(parseError, fields[start])
+	}
+	return &Transaction{
+		Index:       index,
+		Statement:   *result,
+		Information: info,
+	}, i, nil
+}
+
+func newQuarantinedTransaction(fields []field, index int, err error) QuarantinedTransaction {
+	var information []string
+	for _, f := range fields[1:] {
+		information = append(information, f.Value)
+	}
+	return QuarantinedTransaction{
+		Index:       index,
+		Statement:   fields[0].Value,
+		Information: strings.Join(information, "\n"),
+		Errors:      []*ParseError{asParseError(err)},
+	}
+}
+
+type diagnostics struct {
+	lenient    bool
+	validation ValidationLevel
+	errors     []*ParseError
+	warnings   []*ParseError
+}
+
+func newDiagnostics(o options) diagnostics {
+	return diagnostics{lenient: o.isLenient(), validation: o.validation}
+}
+
+func (d *diagnostics) add(err error) error {
+	if !d.lenient {
+		return err
+	}
+	d.errors = append(d.errors, asParseError(err))
+	return nil
+}
+
+func missingTags(seen map[string]bool, mandatory []string) error {
+	var missing []string
+	for _, tag := range mandatory {
+		if !seen[tag] {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		return newParseError(ErrMissingTag, "", "the message is incomplete. Missing tags: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func newStatement(fields []field, messageType MessageType, dialect Dialect, o options) (*Statement, error) {
+	stmt := Statement{MessageType: messageType}
+	report := newDiagnostics(o)
+	if err := report.validateFields(fields); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		seen[f.Tag] = true
+		if tag, ok := intermediateBalanceTags[f.Tag]; ok {
+			seen[tag] = true
+		}
+
+		if f.Tag == transaction {
+			index := len(stmt.Transactions) + len(stmt.Quarantined) + 1
+			result, next, err := nextTransaction(fields, i, index, dialect)
+			if err != nil {
+				if err := report.add(err); err != nil {
+					return nil, err
+				}
+				stmt.Quarantined = append(stmt.Quarantined, newQuarantinedTransaction(fields[i:next+1], index, err))
+			} else {
+				stmt.Transactions = append(stmt.Transactions, *result)
+			}
+			i = next
+			continue
+		}
+		if err := stmt.setField(f, dialect); err != nil {
+			if err := report.add(withPosition(err, f)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := missingTags(seen, mandatoryStatementTags[messageType]); err != nil {
+		if err := report.add(err); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(stmt.ForwardBalances, func(i, j int) bool {
+		return stmt.ForwardBalances[i].Date.Compare(stmt.ForwardBalances[j].Date) < 0
+	})
+	stmt.Errors = report.errors
+	stmt.Warnings = report.warnings
+	return &stmt, nil
+}
+
+func (s *Statement) setField(f field, dialect Dialect) error {
+	switch f.Tag {
+	case referenceNumber:
+		result, err := GetReferenceNumber(f.text())
+		if err != nil {
+			return err
+		}
+		s.ReferenceNumber = *result
+	case relatedReference:
+		result, err := GetRelatedReference(f.text())
+		if err != nil {
+			return err
+		}
+		s.RelatedReference = result
+	case accountIdentification:
+		result, err := dialect.ParseAccount(f.text())
+		if err != nil {
+			return err
+		}
+		s.AccountIdentification = *result
+	case statementNumber:
+		result, err := GetStatementNumber(f.text())
+		if err != nil {
+			return err
+		}
+		s.StatementNumber = *result
+	case balanceStatementNumber:
+		result, err := GetStatementNumber(statementNumber + f.Value + crlf)
+		if err != nil {
+			return err
+		}
+		s.StatementNumber = *result
+	case dateTimeIndication:
+		result, err := GetDateTimeIndication(f.text())
+		if err != nil {
+			return err
+		}
+		s.DateTimeIndication = result
+	case debitEntries:
+		result, err := GetEntrySummary(f.text(), DEBIT)
+		if err != nil {
+			return err
+		}
+		s.DebitEntries = result
+	case creditEntries:
+		result, err := GetEntrySummary(f.text(), CREDIT)
+		if err != nil {
+			return err
+		}
+		s.CreditEntries = result
+	case openingBalance:
+		result, err := GetBalance(f.text(), OPENING)
+		if err != nil {
+			return err
+		}
+		s.OpeningBalance = *result
+	case intermediateOpening:
+		result, err := GetBalance(f.text(), INTERMEDIATE_OPENING)
+		if err != nil {
+			return err
+		}
+		s.OpeningBalance = *result
+	case closingBalance:
+		result, err := GetBalance(f.text(), CLOSING)
+		if err != nil {
+			return err
+		}
+		s.ClosingBalance = *result
+	case intermediateClosing:
+		result, err := GetBalance(f.text(), INTERMEDIATE_CLOSING)
+		if err != nil {
+			return err
+		}
+		s.ClosingBalance = *result
+	case availableBalance:
+		result, err := GetBalance(f.text(), AVAILABLE)
+		if err != nil {
+			return err
+		}
+		s.AvailableBalance = result
+	case forwardBalance:
+		result, err := GetBalance(f.text(), FORWARD_AVAILABLE)
+		if err != nil {
+			return err
+		}
+		s.ForwardBalances = append(s.ForwardBalances, *result)
+	case transactionDescription:
+		if s.Information != "" {
+			s.Information += "\n"
+		}
+		s.Information += f.Value
+	}
+	return nil
+}
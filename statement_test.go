@@ -0,0 +1,252 @@
+package mt940_converter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const statementInput = ":20:STARTUMS\r\n" +
+	":21:NONREF\r\n" +
+	":25:NL17RABO6064103256EUR\r\n" +
+	":28C:00001/001\r\n" +
+	":60F:C230601EUR1000,00\r\n" +
+	":61:2306020602DN2,50NCHGNONREF//BR07282102000059\r\n" +
+	"824-OPŁ. ZA PRZEL. ELIXIR MT\r\n" +
+	":86:824 OPŁATA ZA PRZELEW ELIXIR\r\n" +
+	":61:2306030603CN449,77NTRFSP300//BR05012139000001\r\n" +
+	":86:944 Przelew krajowy\r\n" +
+	"tyt.: fv 100/2007\r\n" +
+	":62F:C230603EUR1447,27\r\n" +
+	":64:C230603EUR1447,27\r\n" +
+	":86:Statement information\r\n" +
+	"-\r\n"
+
+func TestParseStatementCase(t *testing.T) {
+	opening, _ := GetDecimal("1000,00")
+	closing, _ := GetDecimal("1447,27")
+	decim1, _ := GetDecimal("2,50")
+	decim2, _ := GetDecimal("449,77")
+
+	actual, err := ParseStatement(statementInput)
+	assert.Nil(t, err)
+	assert.Equal(t, &Statement{
+		MessageType:      MESSAGE_940,
+		ReferenceNumber:  ReferenceNumber{Value: "STARTUMS"},
+		RelatedReference: &RelatedReference{Value: "NONREF"},
+		AccountIdentification: AccountIdentification{
+			CountryIso: "NL",
+			Iban:       "17RABO6064103256",
+			Currency:   "EUR",
+		},
+		StatementNumber: StatementNumber{Value: "00001/001"},
+		OpeningBalance: Balance{
+			TransactionType: CREDIT,
+			Date:            LongDate{Year: 23, Month: 6, Day: 1},
+			Currency:        "EUR",
+			Amount:          opening,
+			BalanceType:     OPENING,
+		},
+		ClosingBalance: Balance{
+			TransactionType: CREDIT,
+			Date:            LongDate{Year: 23, Month: 6, Day: 3},
+			Currency:        "EUR",
+			Amount:          closing,
+			BalanceType:     CLOSING,
+		},
+		AvailableBalance: &Balance{
+			TransactionType: CREDIT,
+			Date:            LongDate{Year: 23, Month: 6, Day: 3},
+			Currency:        "EUR",
+			Amount:          closing,
+			BalanceType:     AVAILABLE,
+		},
+		Transactions: []Transaction{
+			{
+				Index: 1,
+				Statement: TransactionStatement{
+					ValueDate:            LongDate{Year: 23, Month: 6, Day: 2},
+					EntryDate:            &ShortDate{Month: 6, Day: 2},
+					TransactionType:      DEBIT,
+					FundsCode:            "N",
+					Amount:               decim1,
+					TransactionTypeCode:  "NCHG",
+					OwnerReference:       "NONREF",
+					BankReference:        "BR07282102000059",
+					SupplementaryDetails: "824-OPŁ. ZA PRZEL. ELIXIR MT",
+				},
+				Information: TransactionInformation{Info: "824 OPŁATA ZA PRZELEW ELIXIR"},
+			},
+			{
+				Index: 2,
+				Statement: TransactionStatement{
+					ValueDate:           LongDate{Year: 23, Month: 6, Day: 3},
+					EntryDate:           &ShortDate{Month: 6, Day: 3},
+					TransactionType:     CREDIT,
+					FundsCode:           "N",
+					Amount:              decim2,
+					TransactionTypeCode: "NTRF",
+					OwnerReference:      "SP300",
+					BankReference:       "BR05012139000001",
+				},
+				Information: TransactionInformation{Info: "944 Przelew krajowy\ntyt.: fv 100/2007"},
+			},
+		},
+		Information: "Statement information",
+		Dialect:     DUTCH_DIALECT,
+		Encoding:    ENCODING_UTF8,
+	}, actual)
+	assert.Equal(t, "00001", actual.StatementNumber.Number())
+	assert.Equal(t, "001", actual.StatementNumber.Sequence())
+}
+
+func TestParseStatementLineEndingsCase(t *testing.T) {
+	expected, err := ParseStatement(statementInput)
+	assert.Nil(t, err)
+
+	lf := strings.ReplaceAll(statementInput, "\r\n", "\n")
+	cr := strings.ReplaceAll(statementInput, "\r\n", "\r")
+	mixed := strings.Replace(strings.Replace(statementInput, "\r\n", "\n", 3), "\r\n", "\r", 3)
+	for _, input := range []string{lf, cr, mixed, strings.TrimSuffix(statementInput, "-\r\n")} {
+		actual, err := ParseStatement(input)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestParseMT950Case(t *testing.T) {
+	actual, err := ParseMT950(":20:MT950REF\r\n" +
+		":25:NL17RABO6064103256EUR\r\n" +
+		":28C:00001/001\r\n" +
+		":60F:C230601EUR1000,00\r\n" +
+		":61:2306020602DN2,50NCHGNONREF//BR07282102000059\r\n" +
+		":61:2306030603CN449,77NTRFSP300//BR05012139000001\r\n" +
+		":62F:C230603EUR1447,27\r\n" +
+		"-\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, MessageType(MESSAGE_950), actual.MessageType)
+	assert.Len(t, actual.Transactions, 2)
+	assert.Equal(t, TransactionInformation{}, actual.Transactions[0].Information)
+	assert.Equal(t, TransactionType(CREDIT), actual.Transactions[1].Statement.TransactionType)
+}
+
+func TestParseMT941Case(t *testing.T) {
+	closing, _ := GetDecimal("1447,27")
+	forward, _ := GetDecimal("1500,00")
+
+	actual, err := ParseMT941(mt941Input)
+	assert.Nil(t, err)
+	assert.Equal(t, MessageType(MESSAGE_941), actual.MessageType)
+	assert.Equal(t, "00001", actual.StatementNumber.Number())
+	assert.Equal(t, int64(12), actual.DateTimeIndication.Hour)
+	assert.Equal(t, int64(1), actual.DebitEntries.Count)
+	assert.Equal(t, int64(1), actual.CreditEntries.Count)
+	assert.Equal(t, closing, actual.ClosingBalance.Amount)
+	assert.Equal(t, []Balance{{
+		TransactionType: CREDIT,
+		Date:            LongDate{Year: 23, Month: 6, Day: 4},
+		Currency:        "EUR",
+		Amount:          forward,
+		BalanceType:     FORWARD_AVAILABLE,
+	}}, actual.ForwardBalances)
+	assert.Empty(t, actual.Transactions)
+
+	inferred, err := ParseStatement(mt941Input)
+	assert.Nil(t, err)
+	assert.Equal(t, actual, inferred)
+}
+
+func TestParseStatementRepeatedInformationCase(t *testing.T) {
+	actual, err := ParseStatement(":20:REF\r\n" +
+		":25:NL17RABO6064103256EUR\r\n" +
+		":28C:1\r\n" +
+		":60F:C230601EUR1000,00\r\n" +
+		":61:2306020602CN100,NTRFNONREF\r\n" +
+		":86:FIRST PART\r\n" +
+		":86:SECOND PART\r\n" +
+		":62F:C230602EUR1100,00\r\n" +
+		"-\r\n")
+	assert.Nil(t, err)
+	assert.Len(t, actual.Transactions, 1)
+	assert.Equal(t, "FIRST PART\nSECOND PART", actual.Transactions[0].Information.Info)
+	assert.Empty(t, actual.Information)
+}
+
+func TestParseStatementForwardBalancesCase(t *testing.T) {
+	actual, err := ParseStatement(secondStatementInput +
+		":65:C230610EUR1600,00\r\n" +
+		":65:C230605EUR1500,00\r\n" +
+		":65:D230607EUR100,00\r\n" +
+		"-\r\n")
+	assert.Nil(t, err)
+
+	var dates []LongDate
+	for _, balance := range actual.ForwardBalances {
+		assert.Equal(t, BalanceType(FORWARD_AVAILABLE), balance.BalanceType)
+		dates = append(dates, balance.Date)
+	}
+	assert.Equal(t, []LongDate{
+		{Year: 23, Month: 6, Day: 5},
+		{Year: 23, Month: 6, Day: 7},
+		{Year: 23, Month: 6, Day: 10},
+	}, dates)
+}
+
+func TestParseStatementErrorCase(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+	}
+
+	testTable := []testCase{
+		{name: "Statement is empty", input: ""},
+		{name: "Statement without closing balance", input: ":20:STARTUMS\r\n:25:NL17RABO6064103256EUR\r\n:28C:1\r\n:60F:C230601EUR1000,00\r\n-\r\n"},
+		{name: "Statement with incorrect balance", input: ":20:STARTUMS\r\n:25:NL17RABO6064103256EUR\r\n:28C:1\r\n:60F:C\r\n:62F:C230601EUR1000,00\r\n-\r\n"},
+		{name: "Statement with unsupported message type", input: "{1:F01BANKBEBBAXXX0000000000}{2:I103BANKDEFFXXXXN}{4:\r\n:20:X\r\n-}"},
+		{name: "Statement with too long reference", input: ":20:referenceNumber12\r\n:25:NL17RABO6064103256EUR\r\n:28C:1\r\n:60F:C230601EUR1000,00\r\n:62F:C230601EUR1000,00\r\n"},
+	}
+
+	for _, test := range testTable {
+		actual, err := ParseStatement(test.input)
+		assert.Nil(t, actual, test.name)
+		assert.NotNil(t, err, test.name)
+	}
+}
+
+func TestParseStatementLenientCase(t *testing.T) {
+	input := strings.Replace(statementInput, "2306030603CN449,77", "2306030603XN449,77", 1)
+	input = strings.Replace(input, ":64:C230603EUR1447,27", ":64:C230603EUR", 1)
+
+	actual, err := ParseStatement(input)
+	assert.Nil(t, actual)
+	assert.True(t, errors.Is(err, ErrIncorrectFormat))
+
+	actual, err = ParseStatement(input, WithMode(LENIENT_MODE))
+	assert.Nil(t, err)
+	assert.Len(t, actual.Transactions, 1)
+	assert.Nil(t, actual.AvailableBalance)
+	assert.Equal(t, "STARTUMS", actual.ReferenceNumber.Value)
+	assert.Len(t, actual.Errors, 2)
+	assert.Equal(t, BalanceType(CLOSING), actual.ClosingBalance.BalanceType)
+
+	assert.Len(t, actual.Quarantined, 1)
+	quarantined := actual.Quarantined[0]
+	assert.Equal(t, 2, quarantined.Index)
+	assert.Equal(t, "2306030603XN449,77NTRFSP300//BR05012139000001", quarantined.Statement)
+	assert.Equal(t, "944 Przelew krajowy\ntyt.: fv 100/2007", quarantined.Information)
+	assert.Len(t, quarantined.Errors, 1)
+	assert.Equal(t, 9, quarantined.Errors[0].Line)
+	assert.Equal(t, 2, quarantined.Errors[0].Transaction)
+	assert.Equal(t, availableBalance, actual.Errors[1].Tag)
+	assert.Equal(t, 13, actual.Errors[1].Line)
+}
+
+func TestParseStatementLenientMissingTagsCase(t *testing.T) {
+	actual, err := ParseStatement(":20:STARTUMS\r\n:25:NL17RABO6064103256EUR\r\n-\r\n", WithMode(LENIENT_MODE))
+	assert.Nil(t, err)
+	assert.Equal(t, "STARTUMS", actual.ReferenceNumber.Value)
+	assert.Len(t, actual.Errors, 1)
+	assert.True(t, errors.Is(actual.Errors[0], ErrMissingTag))
+}
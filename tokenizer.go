@@ -0,0 +1,42 @@
+package mt940_converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+const messageEnd = "-"
+
+var tagPattern = regexp.MustCompile(`^:[0-9]{2}[A-Z]?:`)
+
+type field struct {
+	Tag   string
+	Value string
+	Line  int
+}
+
+func (f field) text() string {
+	return f.Tag + f.Value + crlf
+}
+
+func tokenize(input string) []field {
+	var fields []field
+	for i, text := range strings.Split(normalizeLineEndings(input), "\n") {
+		line := i + 1
+
+		if text == messageEnd {
+			break
+		}
+		if text == "" {
+			continue
+		}
+		if tag := tagPattern.FindString(text); tag != "" {
+			fields = append(fields, field{Tag: tag, Value: text[len(tag):], Line: line})
+			continue
+		}
+		if len(fields) > 0 {
+			fields[len(fields)-1].Value += "\n" + text
+		}
+	}
+	return fields
+}
@@ -0,0 +1,162 @@
+package mt940_converter
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ukrainianAccountPattern = regexp.MustCompile(`^(UA[0-9]{27})/?([A-Z]{3})?$`)
+	ukrainianIBANPattern    = regexp.MustCompile(`\bUA[0-9]{27}\b`)
+	ukrainianTaxIDPattern   = regexp.MustCompile(anyCase(ukrainianTaxIDKeywords...) + `[\s:.№N]*([0-9]{8,10})\b`)
+	ukrainianMFOPattern     = regexp.MustCompile(anyCase(ukrainianMFOKeywords...) + `[\s:.]*([0-9]{6})\b`)
+	ukrainianNamePattern    = regexp.MustCompile(anyCase("НАЗВА", "ОТРИМУВАЧ", "ПЛАТНИК", "NAME") + `\s*[:.]\s*(.+)`)
+)
+
+var (
+	ukrainianTaxIDKeywords = []string{"ЄДРПОУ", "ЕДРПОУ", "РНОКПП", "ДРФО", "ІПН", "ИНН", "ОКПО", "EDRPOU", "OKPO"}
+	ukrainianMFOKeywords   = []string{"МФО", "MFO"}
+	ukrainianLatinKeywords = []string{"EDRPOU", "OKPO", "MFO"}
+)
+
+func anyCase(words ...string) string {
+	var result strings.Builder
+	result.WriteString("(?:")
+	for i, word := range words {
+		if i > 0 {
+			result.WriteString("|")
+		}
+		for _, r := range word {
+			upper, lower := unicode.ToUpper(r), unicode.ToLower(r)
+			if upper == lower {
+				result.WriteString(regexp.QuoteMeta(string(r)))
+				continue
+			}
+			result.WriteString("[" + string(upper) + string(lower) + "]")
+		}
+	}
+	result.WriteString(")")
+	return result.String()
+}
+
+func hasUkrainianDetails(input string) bool {
+	if hasCyrillic(input) {
+		return true
+	}
+	for i := 0; i+2 < len(input); i++ {
+		if input[i] == 'U' && input[i+1] == 'A' && input[i+2] >= '0' && input[i+2] <= '9' {
+			return true
+		}
+	}
+	for _, keyword := range ukrainianLatinKeywords {
+		for i := 0; i+len(keyword) <= len(input); i++ {
+			if strings.EqualFold(input[i:i+len(keyword)], keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasCyrillic(input string) bool {
+	if utf8.ValidString(input) {
+		for i := 0; i < len(input); i++ {
+			if input[i] >= 0xD0 && input[i] <= 0xD3 {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i < len(input); i++ {
+		if input[i] >= 0xC0 || input[i] == 0xAA || input[i] == 0xB2 || input[i] == 0xB3 || input[i] == 0xBA {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseUkrainianInformation(input string) (*TransactionInformation, error) {
+	if !hasUkrainianDetails(input) {
+		return nil, newParseError(ErrIncorrectFormat, input, "the information does not contain Ukrainian counterparty details")
+	}
+	info := DecodeCP1251(input)
+	result := TransactionInformation{Info: info}
+
+	for _, segment := range strings.FieldsFunc(info, func(r rune) bool { return r == '\n' || r == '\r' || r == ';' }) {
+		segment = strings.TrimSpace(segment)
+		if matches := ukrainianNamePattern.FindStringSubmatch(segment); matches != nil {
+			result.Counterparty.Name = strings.TrimSpace(matches[1])
+			continue
+		}
+
+		found := false
+		if matches := ukrainianIBANPattern.FindString(segment); matches != "" && result.Counterparty.IBAN == "" {
+			if ValidateUkrainianIBAN(matches) == nil {
+				result.Counterparty.IBAN = matches
+			}
+			found = true
+		}
+		if matches := ukrainianTaxIDPattern.FindStringSubmatch(segment); matches != nil && result.Counterparty.TaxID == "" {
+			result.Counterparty.TaxID = matches[1]
+			found = true
+		}
+		if matches := ukrainianMFOPattern.FindStringSubmatch(segment); matches != nil && result.Counterparty.BankCode == "" {
+			result.Counterparty.BankCode = matches[1]
+			found = true
+		}
+		if !found {
+			if segment != "" {
+				result.Title = append(result.Title, segment)
+			}
+			continue
+		}
+
+		rest := ukrainianIBANPattern.ReplaceAllString(segment, "")
+		rest = ukrainianTaxIDPattern.ReplaceAllString(rest, "")
+		rest = ukrainianMFOPattern.ReplaceAllString(rest, "")
+		if rest = strings.Trim(rest, " ,."); rest != "" && result.Counterparty.Name == "" {
+			result.Counterparty.Name = rest
+		}
+	}
+
+	if result.Counterparty.IBAN == "" && result.Counterparty.TaxID == "" && result.Counterparty.BankCode == "" {
+		return nil, newParseError(ErrIncorrectFormat, input, "the information does not contain Ukrainian counterparty details")
+	}
+	if result.Counterparty.BankCode == "" && result.Counterparty.IBAN != "" {
+		result.Counterparty.BankCode = result.Counterparty.IBAN[4:10]
+	}
+	return &result, nil
+}
+
+func ValidateUkrainianIBAN(iban string) error {
+	if len(iban) != 29 {
+		return newParseError(ErrIncorrectLength, iban, "the Ukrainian IBAN must be 29 characters long. Size: %v", len(iban))
+	}
+	if !strings.HasPrefix(iban, "UA") || !isNumeric(iban[2:]) {
+		return newParseError(ErrIncorrectFormat, iban, "the Ukrainian IBAN must consist of UA and 27 digits")
+	}
+	if !hasValidIBANChecksum(iban) {
+		return newParseError(ErrIncorrectFormat, iban, "the Ukrainian IBAN checksum is incorrect")
+	}
+	return nil
+}
+
+func hasValidIBANChecksum(iban string) bool {
+	var digits strings.Builder
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		default:
+			return false
+		}
+	}
+	number, ok := new(big.Int).SetString(digits.String(), 10)
+	return ok && new(big.Int).Mod(number, big.NewInt(97)).Int64() == 1
+}
@@ -0,0 +1,156 @@
+package mt940_converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ukrainianStatementInput = ":20:UA-STMT-1\r\n" +
+	":25:UA213223130000026007233566001UAH\r\n" +
+	":28C:00015\r\n" +
+	":60F:C230601UAH10000,00\r\n" +
+	":61:2306020602DN1500,00NTRFNONREF\r\n" +
+	":86:\xCE\xEF\xEB\xE0\xF2\xE0 \xE7\xE0 \xF2\xEE\xE2\xE0\xF0\r\n" +
+	"UA223052990000026001234567890 \xD2\xCE\xC2 \"\xD0\xCE\xCC\xC0\xD8\xCA\xC0\" \xAA\xC4\xD0\xCF\xCE\xD3 12345678\r\n" +
+	":62F:C230602UAH8500,00\r\n" +
+	"-\r\n"
+
+func TestParseUkrainianInformationCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult *TransactionInformation
+		hasError       bool
+	}
+
+	testTable := []testCase{
+		{
+			name:  "Counterparty in a single line",
+			input: "Оплата за товар згідно рах. №15\nUA223052990000026001234567890 ТОВ \"РОМАШКА\" ЄДРПОУ 12345678",
+			expectedResult: &TransactionInformation{
+				Info:  "Оплата за товар згідно рах. №15\nUA223052990000026001234567890 ТОВ \"РОМАШКА\" ЄДРПОУ 12345678",
+				Title: []string{"Оплата за товар згідно рах. №15"},
+				Counterparty: Counterparty{
+					Name:     "ТОВ \"РОМАШКА\"",
+					IBAN:     "UA223052990000026001234567890",
+					BankCode: "305299",
+					TaxID:    "12345678",
+				},
+			},
+			hasError: false,
+		},
+		{
+			name:  "Counterparty in keyed segments",
+			input: "Повернення коштів; Отримувач: ФОП Іваненко І.І.; РНОКПП 1234567890; МФО 322313",
+			expectedResult: &TransactionInformation{
+				Info:  "Повернення коштів; Отримувач: ФОП Іваненко І.І.; РНОКПП 1234567890; МФО 322313",
+				Title: []string{"Повернення коштів"},
+				Counterparty: Counterparty{
+					Name:     "ФОП Іваненко І.І.",
+					BankCode: "322313",
+					TaxID:    "1234567890",
+				},
+			},
+			hasError: false,
+		},
+		{
+			name:  "Information in CP1251",
+			input: "\xCE\xEF\xEB\xE0\xF2\xE0; \xAA\xC4\xD0\xCF\xCE\xD3: 12345678",
+			expectedResult: &TransactionInformation{
+				Info:         "Оплата; ЄДРПОУ: 12345678",
+				Title:        []string{"Оплата"},
+				Counterparty: Counterparty{TaxID: "12345678"},
+			},
+			hasError: false,
+		},
+		{
+			name:  "Keywords in lower case",
+			input: "мфо 322313; edrpou 12345678",
+			expectedResult: &TransactionInformation{
+				Info:         "мфо 322313; edrpou 12345678",
+				Counterparty: Counterparty{BankCode: "322313", TaxID: "12345678"},
+			},
+			hasError: false,
+		},
+		{name: "Information with incorrect IBAN checksum", input: "UA213223130000026007233566002", expectedResult: nil, hasError: true},
+		{name: "Information without counterparty details", input: "Оплата за товар", expectedResult: nil, hasError: true},
+	}
+
+	for _, test := range testTable {
+		actual, err := ParseUkrainianInformation(test.input)
+		assert.Equal(t, test.expectedResult, actual, test.name)
+
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestHasUkrainianDetailsCase(t *testing.T) {
+	type testCase struct {
+		name           string
+		input          string
+		expectedResult bool
+	}
+
+	testTable := []testCase{
+		{name: "Cyrillic text", input: "Оплата за товар", expectedResult: true},
+		{name: "Cyrillic text in CP1251", input: "\xCE\xEF\xEB\xE0\xF2\xE0", expectedResult: true},
+		{name: "Ukrainian IBAN", input: "UA213223130000026007233566001", expectedResult: true},
+		{name: "Latin tax ID keyword", input: "Okpo 12345678", expectedResult: true},
+		{name: "Polish text", input: "020?00PRZELEW KRAJOWY?20Opłata za fakturę", expectedResult: false},
+		{name: "German text", input: "166?00SEPA-GUTSCHRIFT?20EREF+E2E-1?21SVWZ+JANUAR 2023", expectedResult: false},
+	}
+
+	for _, test := range testTable {
+		assert.Equal(t, test.expectedResult, hasUkrainianDetails(test.input), test.name)
+	}
+}
+
+func TestValidateUkrainianIBANCase(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		hasError bool
+	}
+
+	testTable := []testCase{
+		{name: "Correct IBAN", input: "UA213223130000026007233566001", hasError: false},
+		{name: "IBAN with incorrect checksum", input: "UA213223130000026007233566002", hasError: true},
+		{name: "IBAN too short", input: "UA2132231300000260072335660", hasError: true},
+		{name: "IBAN with letters", input: "UA21322313000002600723356600A", hasError: true},
+		{name: "Foreign IBAN", input: "PL61109010140000071219812874", hasError: true},
+	}
+
+	for _, test := range testTable {
+		err := ValidateUkrainianIBAN(test.input)
+		if test.hasError {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestParseUkrainianStatementCase(t *testing.T) {
+	actual, err := ParseStatement(ukrainianStatementInput)
+	assert.Nil(t, err)
+	assert.Equal(t, UKRAINIAN_DIALECT, actual.Dialect)
+	assert.Equal(t, AccountIdentification{CountryIso: "UA", Iban: "213223130000026007233566001", Currency: "UAH"}, actual.AccountIdentification)
+
+	information := actual.Transactions[0].Information
+	assert.Equal(t, []string{"Оплата за товар"}, information.Title)
+	assert.Equal(t, Counterparty{
+		Name:     "ТОВ \"РОМАШКА\"",
+		IBAN:     "UA223052990000026001234567890",
+		BankCode: "305299",
+		TaxID:    "12345678",
+	}, information.Counterparty)
+
+	ukrainian, _ := LookupDialect(UKRAINIAN_DIALECT)
+	_, err = ParseStatement(":20:REF\r\n:25:UA213223130000026007233566002UAH\r\n:28C:1\r\n:60F:C230601UAH1,00\r\n:62F:C230601UAH1,00\r\n-\r\n", WithDialect(ukrainian))
+	assert.NotNil(t, err)
+}
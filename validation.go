@@ -0,0 +1,172 @@
+package mt940_converter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ValidationLevel string
+
+const (
+	VALIDATION_OFF    ValidationLevel = "off"
+	VALIDATION_WARN                   = "warn"
+	VALIDATION_STRICT                 = "strict"
+)
+
+const balanceFormat = "1!a6!n3!a15d"
+
+var fieldFormats = map[string]string{
+	referenceNumber:        "16x",
+	relatedReference:       "16x",
+	accountIdentification:  "35x",
+	statementNumber:        "5n[/5n]",
+	balanceStatementNumber: "5n[/2n]",
+	openingBalance:         balanceFormat,
+	closingBalance:         balanceFormat,
+	intermediateOpening:    balanceFormat,
+	intermediateClosing:    balanceFormat,
+	availableBalance:       balanceFormat,
+	forwardBalance:         balanceFormat,
+	transaction:            "6!n[4!n]2a[1!a]15d1!a3!c16x[//16x][\n34x]",
+	transactionDescription: "6*65x",
+	floorLimit:             "3!a[1!a]15d",
+	dateTimeIndication:     "6!n4!n1!x4!n",
+	debitEntries:           "5n3!a15d",
+	creditEntries:          "5n3!a15d",
+}
+
+var characterSets = map[byte]string{
+	'n': `0-9`,
+	'a': `A-Z`,
+	'c': `0-9A-Z`,
+	'd': `0-9,`,
+	'x': `0-9A-Za-z/\-?:().,'+ `,
+}
+
+var formatComponentPattern = regexp.MustCompile(`^([0-9]+)(?:\*([0-9]+))?(!)?([nacdx])`)
+
+var fieldPatterns = make(map[string]*regexp.Regexp)
+
+func init() {
+	for tag, format := range fieldFormats {
+		fieldPatterns[tag] = regexp.MustCompile("^" + formatPattern(format) + "$")
+	}
+}
+
+func formatPattern(format string) string {
+	var result strings.Builder
+	for i := 0; i < len(format); {
+		switch format[i] {
+		case '[':
+			result.WriteString("(?:")
+			i++
+			continue
+		case ']':
+			result.WriteString(")?")
+			i++
+			continue
+		}
+		matches := formatComponentPattern.FindStringSubmatch(format[i:])
+		if matches == nil {
+			result.WriteString(regexp.QuoteMeta(format[i : i+1]))
+			i++
+			continue
+		}
+		i += len(matches[0])
+
+		charset := "[" + characterSets[matches[4][0]] + "]"
+		length := "{1," + matches[1] + "}"
+		if matches[3] != "" {
+			length = "{" + matches[1] + "}"
+		}
+		if matches[4] == "d" {
+			result.WriteString("(?:[0-9]" + charset + "{0," + decrement(matches[1]) + "})")
+			continue
+		}
+		if matches[2] != "" {
+			line := charset + "{1," + matches[2] + "}"
+			result.WriteString(line + "(?:\n" + line + "){0," + decrement(matches[1]) + "}")
+			continue
+		}
+		result.WriteString(charset + length)
+	}
+	return result.String()
+}
+
+func decrement(number string) string {
+	value, _ := strconv.Atoi(number)
+	return strconv.Itoa(value - 1)
+}
+
+func ValidateField(tag string, value string) error {
+	format, ok := fieldFormats[tag]
+	if !ok {
+		return nil
+	}
+	for i, c := range value {
+		if c != '\n' && !isSwiftCharacter(c) {
+			return newValidationError(ErrIncorrectCharacter, tag, value, i, "the character %q is not allowed in the SWIFT x character set", c)
+		}
+	}
+	if !fieldPatterns[tag].MatchString(value) {
+		return newValidationError(ErrIncorrectFormat, tag, value, 0, "the field does not match format %q", format)
+	}
+	if strings.Contains(format, "d") && !hasSingleDecimalComma(value, tag) {
+		return newValidationError(ErrIncorrectAmount, tag, value, 0, "the amount must contain exactly one decimal comma")
+	}
+	return nil
+}
+
+func isSwiftCharacter(c rune) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || strings.ContainsRune(`/-?:().,'+ `, c)
+}
+
+func hasSingleDecimalComma(value string, tag string) bool {
+	line, _, _ := strings.Cut(value, "\n")
+	if tag == transaction {
+		matches := statementPattern.FindStringSubmatch(line)
+		return matches != nil && strings.Count(matches[5], ",") == 1
+	}
+	return strings.Count(line, ",") == 1
+}
+
+func newValidationError(code ErrorCode, tag string, value string, index int, format string, args ...interface{}) *ParseError {
+	line := strings.Count(value[:index], "\n")
+	start := strings.LastIndex(value[:index], "\n") + 1
+	offset := index - start
+	if line == 0 {
+		offset += len(tag)
+	}
+	err := newParseError(code, value[start:], format, args...)
+	err.Tag = tag
+	err.Line = line + 1
+	err.Column = offset + 1
+	return err
+}
+
+func (d *diagnostics) validateFields(fields []field) error {
+	for _, f := range fields {
+		if err := d.validate(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *diagnostics) validate(f field) error {
+	if d.validation == VALIDATION_OFF {
+		return nil
+	}
+	err := ValidateField(f.Tag, f.Value)
+	if err == nil {
+		return nil
+	}
+	parseError := asParseError(err)
+	parseError.Line += f.Line - 1
+	if d.validation == VALIDATION_WARN {
+		d.warnings = append(d.warnings, parseError)
+		return nil
+	}
+	return d.add(parseError)
+}
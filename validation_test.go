@@ -0,0 +1,78 @@
+package mt940_converter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFieldCase(t *testing.T) {
+	type testCase struct {
+		name         string
+		tag          string
+		value        string
+		expectedCode ErrorCode
+	}
+
+	testTable := []testCase{
+		{name: "Reference number", tag: referenceNumber, value: "STARTUMS"},
+		{name: "Reference number too long", tag: referenceNumber, value: "referenceNumber12", expectedCode: ErrIncorrectFormat},
+		{name: "Reference number with incorrect character", tag: referenceNumber, value: "REF_1", expectedCode: ErrIncorrectCharacter},
+		{name: "Account identification", tag: accountIdentification, value: "NL17RABO6064103256EUR"},
+		{name: "Statement number with sequence", tag: statementNumber, value: "00001/001"},
+		{name: "Statement number too long", tag: statementNumber, value: "555555", expectedCode: ErrIncorrectFormat},
+		{name: "Statement number with letters", tag: statementNumber, value: "1A", expectedCode: ErrIncorrectFormat},
+		{name: "Balance", tag: openingBalance, value: "C230601EUR1000,00"},
+		{name: "Balance with lowercase currency", tag: openingBalance, value: "C230601eur1000,00", expectedCode: ErrIncorrectFormat},
+		{name: "Balance without decimal comma", tag: closingBalance, value: "C230601EUR1000", expectedCode: ErrIncorrectAmount},
+		{name: "Balance with two decimal commas", tag: closingBalance, value: "C230601EUR1,000,00", expectedCode: ErrIncorrectAmount},
+		{name: "Transaction", tag: transaction, value: "2306020602DN2,50NCHGNONREF//BR07282102000059\nCard payment"},
+		{name: "Transaction without entry date", tag: transaction, value: "230602C100,NTRFINV-2023-001"},
+		{name: "Transaction with too long reference", tag: transaction, value: "230602C100,NTRFINV-2023-001-0000001", expectedCode: ErrIncorrectFormat},
+		{name: "Information", tag: transactionDescription, value: "944 Przelew krajowy\ntyt.: fv 100/2007"},
+		{name: "Information with too many lines", tag: transactionDescription, value: strings.Repeat("line\n", 6) + "line", expectedCode: ErrIncorrectFormat},
+		{name: "Information with too long line", tag: transactionDescription, value: strings.Repeat("A", 66), expectedCode: ErrIncorrectFormat},
+		{name: "Information with national character", tag: transactionDescription, value: "824 OPŁATA ZA PRZELEW ELIXIR", expectedCode: ErrIncorrectCharacter},
+		{name: "Date time indication", tag: dateTimeIndication, value: "2306031215+0200"},
+		{name: "Unknown tag", tag: ":99:", value: "anything_at_all"},
+	}
+
+	for _, test := range testTable {
+		err := ValidateField(test.tag, test.value)
+		if test.expectedCode == "" {
+			assert.Nil(t, err, test.name)
+		} else {
+			assert.True(t, errors.Is(err, test.expectedCode), test.name)
+		}
+	}
+}
+
+func TestParseStatementValidationCase(t *testing.T) {
+	actual, err := ParseStatement(statementInput)
+	assert.Nil(t, err)
+	assert.Empty(t, actual.Warnings)
+
+	actual, err = ParseStatement(statementInput, WithValidation(VALIDATION_WARN))
+	assert.Nil(t, err)
+	assert.Len(t, actual.Transactions, 2)
+	if assert.Len(t, actual.Warnings, 2) {
+		assert.Equal(t, transaction, actual.Warnings[0].Tag)
+		assert.Equal(t, 7, actual.Warnings[0].Line)
+		assert.Equal(t, 7, actual.Warnings[0].Column)
+		assert.Equal(t, "824-OPŁ. ZA PRZEL. ELIXIR MT", actual.Warnings[0].Snippet)
+		assert.Equal(t, transactionDescription, actual.Warnings[1].Tag)
+		assert.Equal(t, 8, actual.Warnings[1].Line)
+		assert.Equal(t, 11, actual.Warnings[1].Column)
+	}
+
+	actual, err = ParseStatement(statementInput, WithValidation(VALIDATION_STRICT))
+	assert.Nil(t, actual)
+	assert.True(t, errors.Is(err, ErrIncorrectCharacter))
+
+	actual, err = ParseStatement(statementInput, WithValidation(VALIDATION_STRICT), WithMode(LENIENT_MODE))
+	assert.Nil(t, err)
+	assert.Len(t, actual.Errors, 2)
+	assert.Len(t, actual.Transactions, 2)
+}